@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// circuitBreakerThreshold is how many requests in a row have to fail to
+// connect before the breaker opens.
+const circuitBreakerThreshold = 3
+
+// circuitBreakerCooldown is how long the breaker stays open before a
+// single probe request is let through to see if Demo App is back.
+const circuitBreakerCooldown = 10 * time.Second
+
+// errUnreachable is wrapped by the error returned while the breaker is open.
+var errUnreachable = errors.New("unreachable")
+
+// circuitBreaker stops the provider from waiting on a Demo App that is
+// down. Without it, a plan with 30 resources against a crashed Demo App
+// waits out the 30 second HTTP timeout 30 times before failing.
+//
+// The breaker has the usual three states:
+//
+//   - closed: requests go through; connection failures are counted
+//   - open: after circuitBreakerThreshold failures in a row, requests
+//     fail straight away without touching the network
+//   - half-open: once the cooldown has passed, one request is let
+//     through as a probe; if it connects the breaker closes again,
+//     otherwise it reopens for another cooldown
+//
+// Only failures to get a response count. An HTTP error status means Demo
+// App is up and answering, so it resets the count like any other response.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	endpoint string
+	failures int
+	openedAt time.Time
+
+	// probing is set while the half-open probe is in flight, so the
+	// requests around it keep failing fast instead of piling up
+	probing bool
+}
+
+// newCircuitBreaker returns a closed breaker for the given endpoint,
+// which is only used in the error message.
+func newCircuitBreaker(endpoint string) *circuitBreaker {
+	return &circuitBreaker{endpoint: endpoint}
+}
+
+// allow returns an error if the breaker is open, and otherwise lets the
+// request through (as the probe, if the cooldown has just passed).
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < circuitBreakerThreshold {
+		return nil
+	}
+	if b.probing || time.Since(b.openedAt) < circuitBreakerCooldown {
+		return fmt.Errorf("demo-app at %s is %w: the last %d requests failed to connect, not trying again for now",
+			b.endpoint, errUnreachable, b.failures)
+	}
+
+	b.probing = true
+	return nil
+}
+
+// release hands back a request let through by allow that was never
+// sent, so the outcome says nothing about Demo App. If it was the
+// half-open probe, the next request gets to probe instead.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// record updates the breaker with the outcome of a request let through
+// by allow. err is the error from sending the request, if any.
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	// A request cancelled by Terraform says nothing about Demo App
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		if b.failures >= circuitBreakerThreshold {
+			tflog.Info(ctx, "Demo App is reachable again, closing circuit breaker", map[string]any{
+				"endpoint": b.endpoint,
+			})
+		}
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.failures >= circuitBreakerThreshold {
+		if b.failures == circuitBreakerThreshold || b.probing {
+			tflog.Warn(ctx, "Demo App is unreachable, opening circuit breaker", map[string]any{
+				"endpoint": b.endpoint,
+				"failures": b.failures,
+				"error":    err.Error(),
+			})
+		}
+		b.openedAt = time.Now()
+	}
+	b.probing = false
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreakerReleaseProbe checks that a half-open probe that is
+// never sent doesn't leave the breaker stuck open.
+func TestCircuitBreakerReleaseProbe(t *testing.T) {
+	ctx := context.Background()
+	b := newCircuitBreaker("http://demo.example.com")
+
+	for range circuitBreakerThreshold {
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow while closed: %v", err)
+		}
+		b.record(ctx, errors.New("connection refused"))
+	}
+	if err := b.allow(); !errors.Is(err, errUnreachable) {
+		t.Fatalf("allow while open = %v, want errUnreachable", err)
+	}
+
+	// Cooldown over: this request becomes the probe, but is never sent
+	b.openedAt = time.Now().Add(-circuitBreakerCooldown)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after cooldown: %v", err)
+	}
+	b.release()
+
+	// The next request gets to probe, and closes the breaker
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after released probe: %v", err)
+	}
+	b.record(ctx, nil)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after successful probe: %v", err)
+	}
+}
@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// errNotFound is returned by client methods when the API responds 404.
+var errNotFound = errors.New("API returned status 404: not found")
+
+// Do sends an HTTP request to Demo App. Every API call goes through here,
+// so anything that applies to all requests (like authentication) is
+// handled in one place.
+func (c *DemoAppClient) Do(req *http.Request) (*http.Response, error) {
+	// Only set the header when a token is configured; Demo App without
+	// auth in front of it doesn't expect one
+	if c.Token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	// Profile headers never replace one set by the request itself
+	for name, value := range c.Headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	// Anything but a read may change the item list. The snapshot is
+	// dropped again once the write has been answered: a list fetched
+	// while it was in flight may not include it. invalidate waits for a
+	// fetch in progress, so nothing older survives the second drop.
+	write := req.Method != http.MethodGet && req.Method != http.MethodHead
+	if c.itemCache != nil && write {
+		c.itemCache.invalidate()
+		defer c.itemCache.invalidate()
+	}
+
+	// Try each endpoint the pool suggests until one answers. With a
+	// single endpoint this is one attempt, failing fast while its circuit
+	// breaker is open.
+	ctx := req.Context()
+	tracker := trackerFrom(ctx)
+	preferred := ""
+	if tracker != nil {
+		preferred = tracker.Served()
+	}
+
+	var errs []error
+	sent := false
+	for _, endpoint := range c.endpoints.order(req.Method, preferred) {
+		if err := endpoint.breaker.allow(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		attempt, err := requestFor(req, c.Endpoint, endpoint.url, !sent)
+		if err != nil {
+			endpoint.breaker.release()
+			return nil, err
+		}
+		sent = true
+
+		resp, err := c.send(attempt)
+		endpoint.breaker.record(ctx, err)
+		if err == nil {
+			if tracker != nil {
+				tracker.serve(endpoint.url)
+			}
+			return resp, nil
+		}
+
+		errs = append(errs, err)
+
+		// Give up if Terraform cancelled, or if a write may have reached
+		// the server; sending it again elsewhere could apply it twice
+		if ctx.Err() != nil || (write && !notSent(err)) {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// send sends a request to the endpoint it is already aimed at, through
+// the rate limiter when one is configured.
+func (c *DemoAppClient) send(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		return c.doRateLimited(req)
+	}
+	return c.HTTPClient.Do(req)
+}
+
+// ListItems fetches every item currently stored in Demo App.
+// Resources use this when they need to look at the whole inventory
+// rather than a single item (e.g. name conflict checks).
+// With read_cache_ttl set, the list comes from the snapshot cache.
+func (c *DemoAppClient) ListItems(ctx context.Context) ([]itemAPIModel, error) {
+	if c.itemCache != nil {
+		items, _, err := c.itemCache.snapshot(ctx, c.fetchItems)
+		return items, err
+	}
+	return c.fetchItems(ctx)
+}
+
+// fetchItems always lists items from the API, bypassing the cache.
+func (c *DemoAppClient) fetchItems(ctx context.Context) ([]itemAPIModel, error) {
+	return c.listItems(ctx, "/api/items")
+}
+
+// GetItem fetches a single item, returning errNotFound if it doesn't
+// exist. With read_cache_ttl set, it's looked up in the snapshot cache
+// first. An item missing from the snapshot is requested on its own: the
+// snapshot may predate it, or be a single page of a longer list, so only
+// Demo App's 404 means the item is really gone.
+func (c *DemoAppClient) GetItem(ctx context.Context, id string) (*itemAPIModel, error) {
+	if c.itemCache != nil {
+		_, byID, err := c.itemCache.snapshot(ctx, c.fetchItems)
+		if err != nil {
+			return nil, err
+		}
+		if item, ok := byID[id]; ok {
+			return &item, nil
+		}
+	}
+
+	body, err := c.getRaw(ctx, "/api/items/"+id)
+	if err != nil {
+		return nil, err
+	}
+
+	var item itemAPIModel
+	if err := json.Unmarshal([]byte(body), &item); err != nil {
+		return nil, fmt.Errorf("could not parse API response: %w", err)
+	}
+	return &item, nil
+}
+
+// ListItemsPage fetches up to limit items starting at offset. Demo App
+// versions without paging ignore the parameters and return every item,
+// so callers must cope with getting the full list back.
+func (c *DemoAppClient) ListItemsPage(ctx context.Context, offset, limit int) ([]itemAPIModel, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return c.listItems(ctx, "/api/items?"+query.Encode())
+}
+
+// listItems GETs an item list from the given path.
+func (c *DemoAppClient) listItems(ctx context.Context, path string) ([]itemAPIModel, error) {
+	url := c.Endpoint + path
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create HTTP request: %w", err)
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var items []itemAPIModel
+	if err := json.NewDecoder(httpResp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("could not parse API response: %w", err)
+	}
+
+	return items, nil
+}
+
+// CreateItem POSTs a new item and returns the item as stored by Demo App.
+func (c *DemoAppClient) CreateItem(ctx context.Context, item itemAPIModel) (*itemAPIModel, error) {
+	return c.sendItem(ctx, "POST", c.Endpoint+"/api/items", item)
+}
+
+// UpdateItem PUTs new values for an existing item and returns the item as
+// stored by Demo App.
+func (c *DemoAppClient) UpdateItem(ctx context.Context, id string, item itemAPIModel) (*itemAPIModel, error) {
+	return c.sendItem(ctx, "PUT", c.Endpoint+"/api/items/"+id, item)
+}
+
+// DeleteItem removes an item. Items that are already gone are not an error.
+func (c *DemoAppClient) DeleteItem(ctx context.Context, id string) error {
+	url := c.Endpoint + "/api/items/" + id
+	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("could not create HTTP request: %w", err)
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	// 404 is okay - already deleted
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// sendItem writes an item with the given method and decodes the item
+// returned by the API.
+func (c *DemoAppClient) sendItem(ctx context.Context, method, url string, item itemAPIModel) (*itemAPIModel, error) {
+	jsonBody, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("could not create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var apiResponse itemAPIModel
+	if err := json.NewDecoder(httpResp.Body).Decode(&apiResponse); err != nil {
+		return nil, fmt.Errorf("could not parse API response: %w", err)
+	}
+
+	return &apiResponse, nil
+}
+
+// SetDisplay replaces a display panel's content with the given JSON.
+// An empty panel name means the default panel.
+func (c *DemoAppClient) SetDisplay(ctx context.Context, panel, data string) error {
+	url := c.Endpoint + displayPath(panel)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(data))
+	if err != nil {
+		return fmt.Errorf("could not create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	// Any 2xx means the content was accepted
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		body, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetDisplay returns the raw JSON currently shown in a display panel.
+// An empty panel name means the default panel.
+func (c *DemoAppClient) GetDisplay(ctx context.Context, panel string) (string, error) {
+	return c.getRaw(ctx, displayPath(panel))
+}
+
+// GetDisplaySchema returns the JSON Schema for display content, if the
+// demo-app exposes one. Older versions don't, and return errNotFound.
+func (c *DemoAppClient) GetDisplaySchema(ctx context.Context) (string, error) {
+	return c.getRaw(ctx, "/api/schema/display")
+}
+
+// getRaw GETs an API path and returns the response body as-is.
+func (c *DemoAppClient) getRaw(ctx context.Context, path string) (string, error) {
+	url := c.Endpoint + path
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not create HTTP request: %w", err)
+	}
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not read response body: %w", err)
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return "", errNotFound
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return string(body), nil
+}
+
+// EndpointHosts returns the host (and port) of every configured Demo App
+// endpoint, the primary first.
+func (c *DemoAppClient) EndpointHosts() []string {
+	return c.endpoints.hosts()
+}
+
+// forEndpoint returns a client for one specific Demo App instance,
+// regardless of the provider's endpoint settings. demoapp_fleet_item
+// uses it to talk to every instance in its fleet. Clients are reused per
+// endpoint, so an instance that is down is only waited on once.
+//
+// The provider's token, profile headers and TLS settings belong to the
+// provider's own Demo App, so they are only passed on to the instance
+// when shareCredentials is set. Otherwise the instance gets a plain HTTP
+// client and no Authorization header.
+func (c *DemoAppClient) forEndpoint(endpoint string, shareCredentials bool) *DemoAppClient {
+	c.instancesMu.Lock()
+	defer c.instancesMu.Unlock()
+
+	key := fleetInstance{endpoint: endpoint, shareCredentials: shareCredentials}
+	if client, ok := c.instances[key]; ok {
+		return client
+	}
+
+	client := &DemoAppClient{
+		HTTPClient:  &http.Client{Timeout: c.HTTPClient.Timeout},
+		Endpoint:    endpoint,
+		Version:     c.Version,
+		rateLimiter: c.rateLimiter.forInstance(),
+		endpoints:   newEndpointPool([]string{endpoint}, strategyFailover),
+	}
+	if shareCredentials {
+		client.HTTPClient = c.HTTPClient
+		client.Token = c.Token
+		client.Headers = c.Headers
+	}
+	if c.instances == nil {
+		c.instances = make(map[fleetInstance]*DemoAppClient)
+	}
+	c.instances[key] = client
+	return client
+}
+
+// fleetInstance identifies a client handed out by forEndpoint. The same
+// instance can be used with and without the provider's credentials by
+// different fleet items, so both get their own client.
+type fleetInstance struct {
+	endpoint         string
+	shareCredentials bool
+}
+
+// EndpointHost returns the host (and port) of the Demo App endpoint (the
+// primary, with several endpoints), as used in resource identities.
+func (c *DemoAppClient) EndpointHost() string {
+	return endpointHost(c.Endpoint)
+}
+
+// displayPath returns the API path of a display panel. The default panel
+// (empty name) lives at /api/display, named panels at /api/display/{panel}.
+func displayPath(panel string) string {
+	if panel == "" {
+		return "/api/display"
+	}
+	return "/api/display/" + url.PathEscape(panel)
+}
+
+// CreateSession exchanges credentials for a short-lived session token.
+func (c *DemoAppClient) CreateSession(ctx context.Context, username, password string) (*sessionAPIModel, error) {
+	var session sessionAPIModel
+	err := c.postAuth(ctx, "/api/auth/token", map[string]string{
+		"username": username,
+		"password": password,
+	}, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RefreshSession extends a session, returning its new expiry (and a new
+// refresh token, if the API rotates them).
+func (c *DemoAppClient) RefreshSession(ctx context.Context, refreshToken string) (*sessionAPIModel, error) {
+	var session sessionAPIModel
+	err := c.postAuth(ctx, "/api/auth/refresh", map[string]string{
+		"refresh_token": refreshToken,
+	}, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RevokeSession invalidates a session token. Tokens the API no longer
+// knows about (404) are not an error.
+func (c *DemoAppClient) RevokeSession(ctx context.Context, token string) error {
+	err := c.postAuth(ctx, "/api/auth/revoke", map[string]string{
+		"token": token,
+	}, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// postAuth POSTs a JSON body to one of the /api/auth endpoints and decodes
+// the response into out, unless out is nil.
+func (c *DemoAppClient) postAuth(ctx context.Context, path string, body any, out any) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+path, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("could not create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("could not send HTTP request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		// Deliberately not echoing the body: auth errors can quote
+		// back what was sent
+		return fmt.Errorf("API returned status %d", httpResp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not parse API response: %w", err)
+	}
+	return nil
+}
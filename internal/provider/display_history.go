@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDisplayHistoryFile is where display history is kept unless the
+// provider's display_history_file says otherwise. It's relative to the
+// directory Terraform runs in, next to the rest of Terraform's local data.
+const defaultDisplayHistoryFile = ".terraform/demoapp-display-history.json"
+
+// maxDisplayHistory is how many revisions are kept per panel.
+const maxDisplayHistory = 20
+
+// displayHistory is a small JSON file recording what demoapp_display wrote
+// to each panel, so presenters can roll back to an earlier revision.
+//
+// The file is re-read on every call rather than cached, since the plan and
+// apply walks run in different provider processes.
+//
+// Several configurations, or workspaces pointing at different Demo Apps,
+// can share one file, so revisions are kept per Demo App: host is the
+// provider's endpoint host, and every panel is stored under host/panel.
+type displayHistory struct {
+	mu   sync.Mutex
+	path string
+	host string
+}
+
+// displayHistoryFile is the on-disk format.
+type displayHistoryFile struct {
+	// Panels maps a Demo App host and panel ID, e.g.
+	// "localhost:8080/display" for the default panel, to its revisions,
+	// oldest first
+	Panels map[string][]displayRevision `json:"panels"`
+}
+
+// displayRevision is one document written to a panel.
+type displayRevision struct {
+	SHA256    string `json:"sha256"`
+	Data      string `json:"data"`
+	WrittenAt string `json:"written_at"`
+}
+
+// newDisplayHistory returns the history of the Demo App at host, stored
+// at path.
+func newDisplayHistory(path, host string) *displayHistory {
+	return &displayHistory{path: path, host: host}
+}
+
+// key is where the panel's revisions are stored in the file. Hosts can't
+// contain a slash, so host/panel is unambiguous.
+func (h *displayHistory) key(panelID string) string {
+	return h.host + "/" + panelID
+}
+
+// record appends data to the panel's history, dropping the oldest revision
+// once there are more than maxDisplayHistory. Writing the same content as
+// the latest revision again is not recorded.
+func (h *displayHistory) record(panelID, data string) error {
+	hash, err := jsonSHA256([]byte(data))
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := h.load()
+	if err != nil {
+		return err
+	}
+
+	revisions := file.Panels[h.key(panelID)]
+	if n := len(revisions); n > 0 && revisions[n-1].SHA256 == hash {
+		return nil
+	}
+
+	revisions = append(revisions, displayRevision{
+		SHA256:    hash,
+		Data:      data,
+		WrittenAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if len(revisions) > maxDisplayHistory {
+		revisions = revisions[len(revisions)-maxDisplayHistory:]
+	}
+	file.Panels[h.key(panelID)] = revisions
+
+	return h.save(file)
+}
+
+// revisions returns the panel's history, newest first. Index 0 is the
+// latest write, 1 the one before it, and so on.
+func (h *displayHistory) revisions(panelID string) ([]displayRevision, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := file.Panels[h.key(panelID)]
+	newestFirst := make([]displayRevision, len(stored))
+	for i, revision := range stored {
+		newestFirst[len(stored)-1-i] = revision
+	}
+	return newestFirst, nil
+}
+
+// find resolves a rollback_to value: either an index into revisions
+// (0 = latest) or a SHA-256 prefix of at least 7 characters.
+func (h *displayHistory) find(panelID, ref string) (*displayRevision, error) {
+	revisions, err := h.revisions(panelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index, err := strconv.Atoi(ref); err == nil {
+		if index < 0 || index >= len(revisions) {
+			return nil, fmt.Errorf("revision index %d is out of range; panel %q on %s has %d revisions in history", index, panelID, h.host, len(revisions))
+		}
+		return &revisions[index], nil
+	}
+
+	if len(ref) < 7 {
+		return nil, fmt.Errorf("%q is neither a revision index nor a SHA-256 prefix of at least 7 characters", ref)
+	}
+
+	var match *displayRevision
+	for i := range revisions {
+		if !strings.HasPrefix(revisions[i].SHA256, strings.ToLower(ref)) {
+			continue
+		}
+		if match != nil && match.SHA256 != revisions[i].SHA256 {
+			return nil, fmt.Errorf("SHA-256 prefix %q matches more than one revision", ref)
+		}
+		match = &revisions[i]
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no revision of panel %q on %s has a SHA-256 starting with %q", panelID, h.host, ref)
+	}
+	return match, nil
+}
+
+// load reads the history file. A missing file is an empty history.
+func (h *displayHistory) load() (*displayHistoryFile, error) {
+	file := &displayHistoryFile{Panels: map[string][]displayRevision{}}
+
+	content, err := os.ReadFile(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read display history: %w", err)
+	}
+
+	if err := json.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("could not parse display history %s: %w", h.path, err)
+	}
+	if file.Panels == nil {
+		file.Panels = map[string][]displayRevision{}
+	}
+	return file, nil
+}
+
+// save writes the history file, creating its directory if needed.
+// It writes to a temporary file first so a crash can't leave it half written.
+func (h *displayHistory) save(file *displayHistoryFile) error {
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("could not create display history directory: %w", err)
+	}
+
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return fmt.Errorf("could not write display history: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("could not write display history: %w", err)
+	}
+	return nil
+}
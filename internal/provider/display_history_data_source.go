@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ datasource.DataSource = &DisplayHistoryDataSource{}
+var _ datasource.DataSourceWithConfigure = &DisplayHistoryDataSource{}
+
+// DisplayHistoryDataSource lists the revisions demoapp_display has written
+// to a panel, so a presenter can pick one for rollback_to.
+type DisplayHistoryDataSource struct {
+	client *DemoAppClient
+}
+
+// DisplayHistoryDataSourceModel maps to the Terraform configuration.
+type DisplayHistoryDataSourceModel struct {
+	// ID is the panel ID the history belongs to
+	ID types.String `tfsdk:"id"`
+
+	// Panel is the panel name; null means the default panel
+	Panel types.String `tfsdk:"panel"`
+
+	// Revisions are newest first
+	Revisions []displayRevisionModel `tfsdk:"revisions"`
+}
+
+// displayRevisionModel is one entry of the revisions list.
+type displayRevisionModel struct {
+	Index     types.Int64  `tfsdk:"index"`
+	SHA256    types.String `tfsdk:"sha256"`
+	Data      types.String `tfsdk:"data"`
+	WrittenAt types.String `tfsdk:"written_at"`
+}
+
+// NewDisplayHistoryDataSource is the factory function.
+func NewDisplayHistoryDataSource() datasource.DataSource {
+	return &DisplayHistoryDataSource{}
+}
+
+// Metadata sets the data source type name: demoapp_display_history
+func (d *DisplayHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_display_history"
+}
+
+// Schema defines what users can configure.
+func (d *DisplayHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the display content previously written by demoapp_display to a panel, newest first. Use an index or sha256 from here as rollback_to.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The panel ID the history belongs to ('display' for the default panel).",
+				Computed:    true,
+			},
+
+			"panel": schema.StringAttribute{
+				Description: "Name of the panel. Omit for the default panel.",
+				Optional:    true,
+			},
+
+			"revisions": schema.ListNestedAttribute{
+				Description: "Revisions written to the panel, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.Int64Attribute{
+							Description: "Position in the history: 0 is the latest write, 1 the one before, and so on.",
+							Computed:    true,
+						},
+						"sha256": schema.StringAttribute{
+							Description: "SHA-256 of the content in canonical JSON form.",
+							Computed:    true,
+						},
+						"data": schema.StringAttribute{
+							Description: "The JSON content that was written.",
+							Computed:    true,
+						},
+						"written_at": schema.StringAttribute{
+							Description: "When the content was written (RFC 3339, UTC).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (d *DisplayHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read loads the panel's revisions from the history file.
+func (d *DisplayHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DisplayHistoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	panelID := displayID(config.Panel.ValueString())
+	revisions, err := d.client.displayHistory.revisions(panelID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Display History",
+			err.Error(),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(panelID)
+	config.Revisions = make([]displayRevisionModel, len(revisions))
+	for i, revision := range revisions {
+		config.Revisions[i] = displayRevisionModel{
+			Index:     types.Int64Value(int64(i)),
+			SHA256:    types.StringValue(revision.SHA256),
+			Data:      types.StringValue(revision.Data),
+			WrittenAt: types.StringValue(revision.WrittenAt),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestDisplayHistoryPerHost covers two Demo Apps sharing one history
+// file: each must only see, and roll back to, its own revisions.
+func TestDisplayHistoryPerHost(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	local := newDisplayHistory(path, "localhost:8080")
+	staging := newDisplayHistory(path, "staging:8080")
+
+	if err := local.record("display", `{"env":"local"}`); err != nil {
+		t.Fatalf("record local: %v", err)
+	}
+	if err := staging.record("display", `{"env":"staging"}`); err != nil {
+		t.Fatalf("record staging: %v", err)
+	}
+
+	for _, tt := range []struct {
+		history *displayHistory
+		want    string
+	}{
+		{local, `{"env":"local"}`},
+		{staging, `{"env":"staging"}`},
+	} {
+		revisions, err := tt.history.revisions("display")
+		if err != nil {
+			t.Fatalf("revisions on %s: %v", tt.history.host, err)
+		}
+		if len(revisions) != 1 || revisions[0].Data != tt.want {
+			t.Errorf("revisions on %s = %+v, want only %s", tt.history.host, revisions, tt.want)
+		}
+	}
+
+	if _, err := local.find("display", "1"); err == nil {
+		t.Error("find revision 1 on localhost:8080 succeeded, want out of range")
+	}
+}
@@ -1,38 +1,126 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
-
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Compile-time interface check
 var _ resource.Resource = &DisplayResource{}
+var _ resource.ResourceWithModifyPlan = &DisplayResource{}
+var _ resource.ResourceWithImportState = &DisplayResource{}
+var _ resource.ResourceWithConfigValidators = &DisplayResource{}
+var _ resource.ResourceWithValidateConfig = &DisplayResource{}
+var _ resource.ResourceWithIdentity = &DisplayResource{}
+var _ resource.ResourceWithUpgradeState = &DisplayResource{}
+var _ resource.ResourceWithMoveState = &DisplayResource{}
+
+// defaultDisplayID is the resource ID of the default (unnamed) panel.
+const defaultDisplayID = "display"
+
+// displayPanelRegexp restricts panel names to something safe in a URL path.
+var displayPanelRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// What Delete does with the panel, chosen by the on_destroy attribute.
+const (
+	displayOnDestroyClear           = "clear"
+	displayOnDestroyRestorePrevious = "restore_previous"
+	displayOnDestroyLeave           = "leave"
+)
+
+// displayPreviousKey is the private state key holding the content the
+// panel showed before this resource first wrote to it.
+const displayPreviousKey = "previous_content"
+
+// displayEndpointKey is the private state key holding the Demo App
+// endpoint that last served the panel, tried first next time when the
+// provider has several endpoints.
+const displayEndpointKey = "endpoint"
+
+// encodeDisplayEndpoint turns an endpoint into a private state value,
+// which must be JSON. No endpoint removes the key.
+func encodeDisplayEndpoint(endpoint string) []byte {
+	if endpoint == "" {
+		return nil
+	}
+	data, _ := json.Marshal(endpoint)
+	return data
+}
+
+// decodeDisplayEndpoint reads a value written by encodeDisplayEndpoint.
+// A missing or unreadable value means no endpoint is preferred.
+func decodeDisplayEndpoint(data []byte) string {
+	var endpoint string
+	_ = json.Unmarshal(data, &endpoint)
+	return endpoint
+}
 
-// DisplayResource manages the display panel content.
-// Unlike items, there's only ONE display — it's a singleton.
-// Each POST replaces the previous content entirely.
+// DisplayResource manages the content of one display panel.
+// Unlike items, panels aren't created — each one is a singleton
+// identified by name, and each POST replaces its content entirely.
+// Without a panel name we manage the original default panel.
 type DisplayResource struct {
 	client *DemoAppClient
 }
 
 // DisplayResourceModel maps to the Terraform configuration.
-// Just one attribute: the JSON data to display.
 type DisplayResourceModel struct {
-	// ID is required by Terraform but meaningless for a singleton
-	// We'll just use a fixed value like "display"
+	// ID is the panel name, or "display" for the default panel
 	ID types.String `tfsdk:"id"`
 
+	// Panel is the name of the panel to manage; null means the default panel
+	Panel types.String `tfsdk:"panel"`
+
 	// Data is the JSON content to show in the display panel
 	// User passes a JSON string, we POST it to the API
 	Data types.String `tfsdk:"data"`
+
+	// Template renders Data from live item data instead
+	Template types.String `tfsdk:"template"`
+
+	// RollbackTo re-publishes a revision from the display history instead
+	RollbackTo types.String `tfsdk:"rollback_to"`
+
+	// Schema is a JSON Schema (inline or file path) that Data must match
+	Schema types.String `tfsdk:"schema"`
+
+	// SchemaFromApp fetches the JSON Schema from demo-app instead
+	SchemaFromApp types.Bool `tfsdk:"schema_from_app"`
+
+	// IgnoreDeleteErrors turns a failed clear on destroy into a warning
+	IgnoreDeleteErrors types.Bool `tfsdk:"ignore_delete_errors"`
+
+	// OnDestroy is clear, restore_previous or leave
+	OnDestroy types.String `tfsdk:"on_destroy"`
+
+	// ContentSHA256 is the hash of the canonical JSON in Data
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+}
+
+// displayIdentityModel is the resource identity of a panel: which Demo App
+// it's in, and its ID there.
+type displayIdentityModel struct {
+	EndpointHost types.String `tfsdk:"endpoint_host"`
+	Panel        types.String `tfsdk:"panel"`
 }
 
 // NewDisplayResource is the factory function.
@@ -43,6 +131,26 @@ func NewDisplayResource() resource.Resource {
 // Metadata sets the resource type name: demoapp_display
 func (r *DisplayResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_display"
+
+	// endpoint_host follows the provider's endpoint, so it changes when
+	// the same Demo App is reached under another name
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// IdentitySchema defines the resource identity.
+func (r *DisplayResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"endpoint_host": identityschema.StringAttribute{
+				Description:       "Host (and port) of the Demo App the panel is in, e.g. localhost:8080.",
+				OptionalForImport: true,
+			},
+			"panel": identityschema.StringAttribute{
+				Description:       "The panel name, or 'display' for the default panel.",
+				RequiredForImport: true,
+			},
+		},
+	}
 }
 
 // Schema defines what users can configure.
@@ -50,20 +158,206 @@ func (r *DisplayResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Description: "Manages the display panel content in Demo App. Posts arbitrary JSON data that the frontend renders.",
 
+		// Version 1 stores data in canonical JSON form
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Placeholder ID (always 'display' since there's only one display panel).",
+				Description: "The panel name, or 'display' for the default panel.",
 				Computed:    true,
 			},
 
+			"panel": schema.StringAttribute{
+				Description: "Name of the display panel to manage (e.g. 'infra'), served at /api/display/{panel}. Omit to manage the default panel at /api/display. Changing this forces a new resource.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+					stringvalidator.RegexMatches(displayPanelRegexp, "must contain only lowercase letters, digits, '_' and '-', starting with a letter or digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"data": schema.StringAttribute{
-				Description: "JSON string to display. Use jsonencode() to convert HCL to JSON.",
-				Required:    true,
+				Description: "JSON string to display. Use jsonencode() to convert HCL to JSON. Exactly one of data, template or rollback_to must be set; with template or rollback_to, this holds the content that is published.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					validJSON(maxDisplayDataBytes),
+				},
+			},
+
+			"template": schema.StringAttribute{
+				Description: "Go text/template that renders the JSON to display. Rendered by the provider with .Items (live items), .Count, .Panel and .Provider (Endpoint, Version), plus the json and last functions. Re-rendered whenever the inventory changes.",
+				Optional:    true,
+			},
+
+			"rollback_to": schema.StringAttribute{
+				Description: "Re-publish an earlier revision from the display history: an index (0 = latest write, 1 = the one before, ...) or a SHA-256 prefix of at least 7 characters. See the demoapp_display_history data source. Rollbacks are not themselves added to the history.",
+				Optional:    true,
+			},
+
+			"schema": schema.StringAttribute{
+				Description: "JSON Schema that the display data must match, as inline JSON or a path to a schema file. Violations are reported at plan time.",
+				Optional:    true,
+			},
+
+			"schema_from_app": schema.BoolAttribute{
+				Description: "When true, fetch the display JSON Schema from Demo App (/api/schema/display) and validate data against it at plan time. Conflicts with schema.",
+				Optional:    true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("schema")),
+				},
+			},
+
+			"ignore_delete_errors": schema.BoolAttribute{
+				Description: "When true, a failure to clear the display on destroy is reported as a warning instead of an error. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens to the panel on destroy: 'clear' posts {}, 'restore_previous' puts back the content shown before this resource was created, 'leave' keeps the current content. Defaults to 'clear'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(displayOnDestroyClear),
+				Validators: []validator.String{
+					stringvalidator.OneOf(displayOnDestroyClear, displayOnDestroyRestorePrevious, displayOnDestroyLeave),
+				},
+			},
+
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 of the display content in canonical JSON form. Changes whenever the content changes, in Terraform or out of band.",
+				Computed:    true,
 			},
 		},
 	}
 }
 
+// UpgradeState migrates state written by earlier provider versions.
+func (r *DisplayResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeDisplayStateV0},
+	}
+}
+
+// upgradeDisplayStateV0 converts a version 0 display state. Early versions
+// copied the API response into data byte for byte, trailing newline and
+// all, so data is rewritten in the form jsonencode() produces. Attributes
+// added since then get their defaults.
+func upgradeDisplayStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	raw, err := decodeRawState(req.RawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Display State",
+			err.Error(),
+		)
+		return
+	}
+
+	state := DisplayResourceModel{
+		Panel:              raw.String("panel"),
+		Data:               raw.String("data"),
+		Template:           raw.String("template"),
+		RollbackTo:         raw.String("rollback_to"),
+		Schema:             raw.String("schema"),
+		SchemaFromApp:      raw.Bool("schema_from_app"),
+		IgnoreDeleteErrors: raw.Bool("ignore_delete_errors"),
+		OnDestroy:          raw.String("on_destroy"),
+	}
+	state.ID = types.StringValue(displayID(state.Panel.ValueString()))
+
+	// Invalid JSON is left as-is; the next plan reports it
+	if !state.Data.IsNull() {
+		if encoded, err := jsonencodeForm([]byte(state.Data.ValueString())); err == nil {
+			state.Data = types.StringValue(string(encoded))
+		}
+		state.ContentSHA256 = displayContentHash(state.Data.ValueString())
+	} else {
+		state.ContentSHA256 = types.StringNull()
+	}
+
+	if state.IgnoreDeleteErrors.IsNull() {
+		state.IgnoreDeleteErrors = types.BoolValue(false)
+	}
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(displayOnDestroyClear)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// MoveState lets a moved block turn a restapi_object that was POSTed to
+// /api/display (or /api/display/{panel}) into a demoapp_display.
+func (r *DisplayResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveFromRestapiObject},
+	}
+}
+
+// moveFromRestapiObject converts a restapi_object state. The panel comes
+// from the path and the content from what the restapi provider sent.
+func (r *DisplayResource) moveFromRestapiObject(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	object, err := readRestapiObject(req)
+	if object == nil && err == nil {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Moving Display",
+			err.Error(),
+		)
+		return
+	}
+
+	// /api/display is the default panel, /api/display/{panel} a named one
+	panel, named := strings.CutPrefix(object.Path, displayPath("")+"/")
+	if !named {
+		panel = ""
+	}
+	if valid := object.Path == displayPath("") || (named && displayPanelRegexp.MatchString(panel)); !valid {
+		resp.Diagnostics.AddError(
+			"Error Moving Display",
+			fmt.Sprintf("Only restapi_object resources with path \"/api/display\" or \"/api/display/{panel}\" can be moved to demoapp_display, got %q.", object.Path),
+		)
+		return
+	}
+
+	data := object.Data
+	if data == "" {
+		data = object.APIResponse
+	}
+	// Written the way jsonencode() writes it, so the configuration that
+	// takes over the panel doesn't show a diff
+	encoded, err := jsonencodeForm([]byte(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Moving Display",
+			"The restapi_object's data is not valid JSON: "+err.Error(),
+		)
+		return
+	}
+
+	state := DisplayResourceModel{
+		ID:                 types.StringValue(displayID(panel)),
+		Panel:              types.StringNull(),
+		Data:               types.StringValue(string(encoded)),
+		IgnoreDeleteErrors: types.BoolValue(false),
+		OnDestroy:          types.StringValue(displayOnDestroyClear),
+		ContentSHA256:      displayContentHash(string(encoded)),
+	}
+	if panel != "" {
+		state.Panel = types.StringValue(panel)
+	}
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &state)...)
+
+	if r.client != nil && resp.TargetIdentity != nil {
+		resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, displayIdentity(r.client, panel))...)
+	}
+}
+
 // Configure receives the provider's HTTP client.
 func (r *DisplayResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -82,6 +376,109 @@ func (r *DisplayResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ConfigValidators requires exactly one of data, template or rollback_to.
+func (r *DisplayResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("data"),
+			path.MatchRoot("template"),
+			path.MatchRoot("rollback_to"),
+		),
+	}
+}
+
+// ValidateConfig checks data against a local schema (inline or file) during
+// terraform validate. Schemas fetched from demo-app need the provider to be
+// configured, so those are checked in ModifyPlan instead.
+func (r *DisplayResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DisplayResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Schema.IsNull() || config.Schema.IsUnknown() || config.Data.IsNull() || config.Data.IsUnknown() {
+		return
+	}
+
+	schemaText, diags := loadDisplaySchema(ctx, nil, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDisplaySchema(schemaText, config.Data.ValueString())...)
+}
+
+// ModifyPlan renders templates, validates data against a schema fetched
+// from demo-app or a rendered template, and fills in content_sha256 from
+// the planned data, so a change of content shows up as a hash change in
+// the plan.
+func (r *DisplayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DisplayResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rollbacks publish a revision from history, which we can show in the plan
+	if !plan.RollbackTo.IsNull() && !plan.RollbackTo.IsUnknown() && r.client != nil {
+		revision, err := r.client.displayHistory.find(displayID(plan.Panel.ValueString()), plan.RollbackTo.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rollback_to"),
+				"Invalid Display Rollback",
+				err.Error(),
+			)
+			return
+		}
+		plan.Data = types.StringValue(revision.Data)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data"), plan.Data)...)
+	}
+
+	// Templates are rendered now to catch errors early, and again at apply
+	if !plan.Template.IsNull() && !plan.Template.IsUnknown() && r.client != nil {
+		plan.Data = r.planTemplate(ctx, req, resp, plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data"), plan.Data)...)
+	}
+
+	// Leave the hash unknown until the data is known
+	if plan.Data.IsUnknown() {
+		return
+	}
+
+	// Plain data with a local schema was already checked in ValidateConfig
+	if plan.SchemaFromApp.ValueBool() || !plan.Template.IsNull() {
+		schemaText, diags := loadDisplaySchema(ctx, r.client, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if schemaText != "" {
+			resp.Diagnostics.Append(validateDisplaySchema(schemaText, plan.Data.ValueString())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	hash, err := jsonSHA256([]byte(plan.Data.ValueString()))
+	if err != nil {
+		// The data validator reports invalid JSON
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), hash)...)
+}
+
 // Create posts the JSON data to the display endpoint.
 func (r *DisplayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan DisplayResourceModel
@@ -90,7 +487,14 @@ func (r *DisplayResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	// Work out the content from a template or the history, if needed
+	resp.Diagnostics.Append(r.resolveData(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Validate that the data is valid JSON
+	// The schema validator skips values that were unknown at plan time
 	if !json.Valid([]byte(plan.Data.ValueString())) {
 		resp.Diagnostics.AddError(
 			"Invalid JSON",
@@ -99,41 +503,54 @@ func (r *DisplayResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// POST the JSON to /api/display
-	url := r.client.Endpoint + "/api/display"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(plan.Data.ValueString()))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Display",
-			"Could not create HTTP request: "+err.Error(),
-		)
+	// Check the data against the schema; rendered templates weren't
+	// necessarily known at plan time
+	resp.Diagnostics.Append(r.checkSchema(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
-	if err != nil {
+	// Remember what the panel showed before we take it over, so
+	// on_destroy = "restore_previous" can put it back later
+	ctx, served := withEndpointTracker(ctx, "")
+	previous, err := r.client.GetDisplay(ctx, plan.Panel.ValueString())
+	if errors.Is(err, errNotFound) {
+		// A panel that doesn't exist yet is restored by clearing it
+		previous, err = "{}", nil
+	}
+	switch {
+	case err == nil && json.Valid([]byte(previous)):
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, displayPreviousKey, []byte(previous))...)
+	case plan.OnDestroy.ValueString() == displayOnDestroyRestorePrevious:
+		detail := "The display returned content that is not valid JSON."
+		if err != nil {
+			detail = err.Error()
+		}
 		resp.Diagnostics.AddError(
 			"Error Creating Display",
-			"Could not send HTTP request: "+err.Error(),
+			"Could not capture the previous display content for on_destroy = \"restore_previous\": "+detail,
 		)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(httpResp.Body)
+	// POST the JSON to /api/display and read it back
+	if err := r.write(ctx, plan.Panel.ValueString(), plan.Data.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Display",
-			fmt.Sprintf("API returned status %d: %s", httpResp.StatusCode, string(body)),
+			err.Error(),
 		)
 		return
 	}
 
-	// Set the ID (fixed value since display is a singleton)
-	plan.ID = types.StringValue("display")
+	resp.Diagnostics.Append(r.recordHistory(plan)...)
+
+	// The panel name doubles as the ID
+	plan.ID = types.StringValue(displayID(plan.Panel.ValueString()))
+	plan.ContentSHA256 = displayContentHash(plan.Data.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, displayIdentity(r.client, plan.Panel.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, displayEndpointKey, encodeDisplayEndpoint(served.Served()))...)
 }
 
 // Read fetches the current display content.
@@ -144,51 +561,56 @@ func (r *DisplayResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// GET the current display content
-	url := r.client.Endpoint + "/api/display"
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Display",
-			"Could not create HTTP request: "+err.Error(),
-		)
-		return
+	// Warn if the identity names another Demo App host; see
+	// warnIdentityEndpoint
+	if req.Identity != nil && !req.Identity.Raw.IsNull() {
+		var identity displayIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(warnIdentityEndpoint(r.client, identity.EndpointHost)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Display",
-			"Could not send HTTP request: "+err.Error(),
-		)
-		return
-	}
-	defer httpResp.Body.Close()
+	// GET the current display content, from the endpoint that served it
+	// last time
+	endpoint, diags := req.Private.GetKey(ctx, displayEndpointKey)
+	resp.Diagnostics.Append(diags...)
+	ctx, served := withEndpointTracker(ctx, decodeDisplayEndpoint(endpoint))
+	body, err := r.client.GetDisplay(ctx, state.Panel.ValueString())
 
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		resp.Diagnostics.AddError(
-			"Error Reading Display",
-			fmt.Sprintf("API returned status %d: %s", httpResp.StatusCode, string(body)),
-		)
+	// A named panel that no longer exists needs to be written again
+	if errors.Is(err, errNotFound) && !state.Panel.IsNull() {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	// Read the response body as raw JSON
-	body, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Display",
-			"Could not read response body: "+err.Error(),
+			err.Error(),
 		)
 		return
 	}
 
-	// Update state with current data from API
-	state.Data = types.StringValue(string(body))
-	state.ID = types.StringValue("display")
+	// Only replace data when the content really changed. The API may
+	// format JSON differently than jsonencode(), which shouldn't be drift.
+	if !jsonEqual([]byte(body), []byte(state.Data.ValueString())) {
+		state.Data = types.StringValue(body)
+	}
+	state.ID = types.StringValue(displayID(state.Panel.ValueString()))
+	state.ContentSHA256 = displayContentHash(state.Data.ValueString())
+
+	// Older state (or an import) has no ignore_delete_errors/on_destroy yet
+	if state.IgnoreDeleteErrors.IsNull() {
+		state.IgnoreDeleteErrors = types.BoolValue(false)
+	}
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(displayOnDestroyClear)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, displayIdentity(r.client, state.Panel.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, displayEndpointKey, encodeDisplayEndpoint(served.Served()))...)
 }
 
 // Update is the same as Create for display — just POST new content.
@@ -199,6 +621,12 @@ func (r *DisplayResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	// Work out the content (same as Create)
+	resp.Diagnostics.Append(r.resolveData(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Validate JSON
 	if !json.Valid([]byte(plan.Data.ValueString())) {
 		resp.Diagnostics.AddError(
@@ -208,65 +636,268 @@ func (r *DisplayResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// POST the new content (same as Create)
-	url := r.client.Endpoint + "/api/display"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(plan.Data.ValueString()))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Display",
-			"Could not create HTTP request: "+err.Error(),
-		)
+	// Check the data against the schema (same as Create)
+	resp.Diagnostics.Append(r.checkSchema(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
-	if err != nil {
+	// POST the new content (same as Create), preferring the endpoint
+	// the panel was last served by
+	endpoint, diags := req.Private.GetKey(ctx, displayEndpointKey)
+	resp.Diagnostics.Append(diags...)
+	ctx, served := withEndpointTracker(ctx, decodeDisplayEndpoint(endpoint))
+	if err := r.write(ctx, plan.Panel.ValueString(), plan.Data.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Display",
-			"Could not send HTTP request: "+err.Error(),
+			err.Error(),
 		)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(httpResp.Body)
-		resp.Diagnostics.AddError(
-			"Error Updating Display",
-			fmt.Sprintf("API returned status %d: %s", httpResp.StatusCode, string(body)),
-		)
-		return
-	}
+	resp.Diagnostics.Append(r.recordHistory(plan)...)
 
-	plan.ID = types.StringValue("display")
+	plan.ID = types.StringValue(displayID(plan.Panel.ValueString()))
+	plan.ContentSHA256 = displayContentHash(plan.Data.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, displayIdentity(r.client, plan.Panel.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, displayEndpointKey, encodeDisplayEndpoint(served.Served()))...)
 }
 
-// Delete clears the display by posting empty JSON.
+// Delete clears the display, restores what it showed before, or leaves it,
+// depending on on_destroy.
 func (r *DisplayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// For display, "delete" means clear it — post empty object
-	url := r.client.Endpoint + "/api/display"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString("{}"))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Display",
-			"Could not create HTTP request: "+err.Error(),
+	var state DisplayResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// By default, "delete" means clear it — post empty object
+	content := "{}"
+
+	switch state.OnDestroy.ValueString() {
+	case displayOnDestroyLeave:
+		return
+
+	case displayOnDestroyRestorePrevious:
+		previous, diags := req.Private.GetKey(ctx, displayPreviousKey)
+		resp.Diagnostics.Append(diags...)
+		if len(previous) > 0 {
+			content = string(previous)
+		} else {
+			// Imported displays never captured anything to restore
+			resp.Diagnostics.AddWarning(
+				"No Previous Display Content",
+				"This display has no recorded previous content to restore, so it was cleared instead.",
+			)
+		}
+	}
+
+	endpoint, diags := req.Private.GetKey(ctx, displayEndpointKey)
+	resp.Diagnostics.Append(diags...)
+	ctx, _ = withEndpointTracker(ctx, decodeDisplayEndpoint(endpoint))
+
+	err := r.write(ctx, state.Panel.ValueString(), content)
+	if err == nil {
+		return
+	}
+
+	// Either way Terraform removes the resource from state; the
+	// difference is whether the destroy is reported as failed
+	if state.IgnoreDeleteErrors.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Display Not Cleared",
+			err.Error()+"\n\nIgnored because ignore_delete_errors is set.",
 		)
 		return
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
+	resp.Diagnostics.AddError(
+		"Error Deleting Display",
+		err.Error(),
+	)
+}
+
+// planTemplate renders the template for the plan and returns the planned data.
+//
+// The rendered output is only planned as a known value when it matches
+// what the panel already shows. Otherwise data is left unknown and
+// rendered again at apply: items created earlier in the same apply would
+// change the output, and a known planned value that changes at apply makes
+// Terraform fail with "Provider produced inconsistent final plan".
+func (r *DisplayResource) planTemplate(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan DisplayResourceModel) types.String {
+	rendered, err := renderDisplayTemplate(ctx, r.client, plan.Panel.ValueString(), plan.Template.ValueString())
 	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template"),
+			"Error Rendering Display Template",
+			err.Error(),
+		)
+		return types.StringUnknown()
+	}
+
+	if req.State.Raw.IsNull() {
+		return types.StringUnknown()
+	}
+
+	var state DisplayResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return types.StringUnknown()
+	}
+
+	if jsonEqual([]byte(rendered), []byte(state.Data.ValueString())) {
+		return state.Data
+	}
+	return types.StringUnknown()
+}
+
+// resolveData fills in m.Data at apply time when it comes from a template
+// or the display history rather than straight from the configuration.
+func (r *DisplayResource) resolveData(ctx context.Context, m *DisplayResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch {
+	case !m.Template.IsNull():
+		// Render the template with the inventory as it is right now
+		rendered, err := renderDisplayTemplate(ctx, r.client, m.Panel.ValueString(), m.Template.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("template"),
+				"Error Rendering Display Template",
+				err.Error(),
+			)
+			return diags
+		}
+		m.Data = types.StringValue(rendered)
+
+	case !m.RollbackTo.IsNull() && m.Data.IsUnknown():
+		// Normally resolved in the plan; only unknown if rollback_to was
+		revision, err := r.client.displayHistory.find(displayID(m.Panel.ValueString()), m.RollbackTo.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("rollback_to"),
+				"Invalid Display Rollback",
+				err.Error(),
+			)
+			return diags
+		}
+		m.Data = types.StringValue(revision.Data)
+	}
+
+	return diags
+}
+
+// recordHistory adds what was just written to the display history.
+// Rollbacks aren't recorded, so revision indexes stay put while a
+// rollback_to is in place. A history failure doesn't fail the apply.
+func (r *DisplayResource) recordHistory(m DisplayResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.RollbackTo.IsNull() {
+		return diags
+	}
+
+	if err := r.client.displayHistory.record(displayID(m.Panel.ValueString()), m.Data.ValueString()); err != nil {
+		diags.AddWarning(
+			"Display History Not Updated",
+			"The display was written, but could not be added to the display history: "+err.Error(),
+		)
+	}
+	return diags
+}
+
+// checkSchema validates the data in m against its configured schema, if any.
+func (r *DisplayResource) checkSchema(ctx context.Context, m DisplayResourceModel) diag.Diagnostics {
+	schemaText, diags := loadDisplaySchema(ctx, r.client, m)
+	if diags.HasError() || schemaText == "" {
+		return diags
+	}
+
+	diags.Append(validateDisplaySchema(schemaText, m.Data.ValueString())...)
+	return diags
+}
+
+// ImportState imports a panel by name, given either as the import ID or
+// as panel in an import block's identity. The name "display" imports the
+// default panel.
+func (r *DisplayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if id == "" {
+		var identity displayIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(checkIdentityEndpoint(r.client, identity.EndpointHost)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		id = identity.Panel.ValueString()
+	}
+
+	if id != defaultDisplayID && !displayPanelRegexp.MatchString(id) {
 		resp.Diagnostics.AddError(
-			"Error Deleting Display",
-			"Could not send HTTP request: "+err.Error(),
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a panel name or %q for the default panel, got: %q", defaultDisplayID, id),
 		)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	// We don't really care about the response for delete
-	// Just let Terraform remove it from state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	if id != defaultDisplayID {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("panel"), id)...)
+	}
+
+	// Set the complete identity now, so an omitted endpoint_host doesn't
+	// look like an identity change when Read fills it in
+	panel := id
+	if id == defaultDisplayID {
+		panel = ""
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, displayIdentity(r.client, panel))...)
+}
+
+// write POSTs data to the panel and reads it back, returning an error if
+// the panel doesn't end up showing the same JSON. This catches writes that
+// failed silently and backends that rewrite the payload.
+func (r *DisplayResource) write(ctx context.Context, panel, data string) error {
+	if err := r.client.SetDisplay(ctx, panel, data); err != nil {
+		return fmt.Errorf("Could not set display content: %w", err)
+	}
+
+	live, err := r.client.GetDisplay(ctx, panel)
+	if err != nil {
+		return fmt.Errorf("Could not read back display content: %w", err)
+	}
+
+	if !jsonEqual([]byte(live), []byte(data)) {
+		return fmt.Errorf("Display content was not applied as written.\n\nWrote: %s\nRead back: %s", data, live)
+	}
+
+	return nil
+}
+
+// displayID returns the resource ID for a panel name.
+func displayID(panel string) string {
+	if panel == "" {
+		return defaultDisplayID
+	}
+	return panel
+}
+
+// displayIdentity returns the identity of a panel in the client's Demo App.
+func displayIdentity(client *DemoAppClient, panel string) displayIdentityModel {
+	return displayIdentityModel{
+		EndpointHost: types.StringValue(client.EndpointHost()),
+		Panel:        types.StringValue(displayID(panel)),
+	}
+}
+
+// displayContentHash returns content_sha256 for data, or null if data isn't
+// valid JSON.
+func displayContentHash(data string) types.String {
+	hash, err := jsonSHA256([]byte(data))
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(hash)
 }
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// loadDisplaySchema returns the JSON Schema text configured on a display:
+// inline JSON or a file path in schema, or fetched from demo-app when
+// schema_from_app is set. It returns "" when there is no schema to check.
+func loadDisplaySchema(ctx context.Context, client *DemoAppClient, m DisplayResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !m.Schema.IsNull() && !m.Schema.IsUnknown() {
+		text, err := readDisplaySchema(m.Schema.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("schema"), "Invalid Display Schema", err.Error())
+		}
+		return text, diags
+	}
+
+	if !m.SchemaFromApp.ValueBool() || client == nil {
+		return "", diags
+	}
+
+	text, err := client.GetDisplaySchema(ctx)
+	if errors.Is(err, errNotFound) {
+		diags.AddAttributeWarning(
+			path.Root("schema_from_app"),
+			"Display Schema Not Available",
+			"Demo App does not expose a display schema, so the display data was not validated.",
+		)
+		return "", diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("schema_from_app"),
+			"Error Fetching Display Schema",
+			err.Error(),
+		)
+	}
+	return text, diags
+}
+
+// readDisplaySchema treats value as inline JSON when it starts with '{'
+// and as a file path otherwise.
+func readDisplaySchema(value string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return value, nil
+	}
+
+	content, err := os.ReadFile(value)
+	if err != nil {
+		return "", fmt.Errorf("could not read schema file: %w", err)
+	}
+	return string(content), nil
+}
+
+// validateDisplaySchema checks data against a JSON Schema. Each violation
+// becomes an error on the data attribute, prefixed with the JSON pointer of
+// the offending value.
+func validateDisplaySchema(schemaText, data string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("display-schema.json", strings.NewReader(schemaText)); err != nil {
+		diags.AddAttributeError(path.Root("schema"), "Invalid Display Schema", err.Error())
+		return diags
+	}
+	schema, err := compiler.Compile("display-schema.json")
+	if err != nil {
+		diags.AddAttributeError(path.Root("schema"), "Invalid Display Schema", err.Error())
+		return diags
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(data)))
+	dec.UseNumber()
+	var instance any
+	if err := dec.Decode(&instance); err != nil {
+		// The data validator reports invalid JSON
+		return diags
+	}
+
+	err = schema.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return diags
+	}
+
+	for _, leaf := range schemaViolations(validationErr) {
+		pointer := leaf.InstanceLocation
+		if pointer == "" {
+			pointer = "/"
+		}
+		diags.AddAttributeError(
+			path.Root("data"),
+			"Display Data Does Not Match Schema",
+			fmt.Sprintf("%s: %s", pointer, leaf.Message),
+		)
+	}
+
+	return diags
+}
+
+// schemaViolations flattens a validation error tree into its leaves,
+// which are the individual violations worth reporting.
+func schemaViolations(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, schemaViolations(cause)...)
+	}
+	return leaves
+}
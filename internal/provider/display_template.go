@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"text/template"
+)
+
+// displayTemplateContext is what a demoapp_display template is rendered
+// with, i.e. the "." inside {{ }}.
+type displayTemplateContext struct {
+	// Items is the live item list from Demo App
+	Items []itemAPIModel
+
+	// Count is len(Items)
+	Count int
+
+	// Panel is the panel being rendered ("" for the default panel)
+	Panel string
+
+	// Provider describes the provider doing the rendering
+	Provider displayTemplateProvider
+}
+
+// displayTemplateProvider is the .Provider part of the template context.
+type displayTemplateProvider struct {
+	Endpoint string
+	Version  string
+}
+
+// displayTemplateFuncs are the extra functions available in templates.
+var displayTemplateFuncs = template.FuncMap{
+	// json encodes any value as JSON, e.g. {{ json .Name }} for a quoted,
+	// escaped string
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+
+	// last reports whether index i is the last element of list, for
+	// leaving out the trailing comma in {{ range $i, $item := .Items }}
+	"last": func(i int, list any) bool {
+		v := reflect.ValueOf(list)
+		return i == v.Len()-1
+	},
+}
+
+// renderDisplayTemplate renders a display template against the live item
+// list and checks that the result is JSON the display can take.
+func renderDisplayTemplate(ctx context.Context, client *DemoAppClient, panel, text string) (string, error) {
+	tmpl, err := template.New("display").Funcs(displayTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse template: %w", err)
+	}
+
+	items, err := client.ListItems(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not list items: %w", err)
+	}
+
+	data := displayTemplateContext{
+		Items: items,
+		Count: len(items),
+		Panel: panel,
+		Provider: displayTemplateProvider{
+			Endpoint: client.Endpoint,
+			Version:  client.Version,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not render template: %w", err)
+	}
+
+	rendered := buf.String()
+	if len(rendered) > maxDisplayDataBytes {
+		return "", fmt.Errorf("rendered template is %d bytes; the maximum is %d bytes", len(rendered), maxDisplayDataBytes)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return "", fmt.Errorf("rendered template is not valid JSON:\n%s", rendered)
+	}
+
+	return rendered, nil
+}
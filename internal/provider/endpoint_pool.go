@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Values of the provider's strategy attribute.
+const (
+	// strategyFailover sends everything to the first endpoint that is up,
+	// in the order they are listed
+	strategyFailover = "failover"
+
+	// strategyRoundRobin spreads requests across all endpoints
+	strategyRoundRobin = "round_robin"
+
+	// strategyPrimaryForWrites sends writes to the first endpoint only and
+	// spreads reads across the others (the replicas)
+	strategyPrimaryForWrites = "primary_for_writes"
+)
+
+var endpointStrategies = []string{strategyFailover, strategyRoundRobin, strategyPrimaryForWrites}
+
+// endpointPool is the set of Demo App endpoints the provider talks to.
+// With a single endpoint (the usual case) it does nothing but hold that
+// endpoint's circuit breaker. With several, DemoAppClient.Do asks it which
+// endpoints to try for each request, in order.
+//
+// Health is tracked by each endpoint's circuit breaker: an endpoint whose
+// breaker is open is skipped until its cooldown has passed.
+type endpointPool struct {
+	strategy  string
+	endpoints []*poolEndpoint
+
+	// next rotates the starting endpoint for round_robin, and the first
+	// replica for primary_for_writes
+	next atomic.Uint64
+}
+
+// poolEndpoint is one Demo App endpoint and its health.
+type poolEndpoint struct {
+	url     string
+	breaker *circuitBreaker
+}
+
+// newEndpointPool returns a pool over urls, the first being the primary.
+func newEndpointPool(urls []string, strategy string) *endpointPool {
+	pool := &endpointPool{strategy: strategy}
+	for _, u := range urls {
+		pool.endpoints = append(pool.endpoints, &poolEndpoint{
+			url:     u,
+			breaker: newCircuitBreaker(u),
+		})
+	}
+	return pool
+}
+
+// order returns the endpoints to try for a request, best first.
+// preferred, if it is one of the endpoints, is the one that served the
+// resource before and goes first so reads stay consistent with writes.
+// Writes with primary_for_writes only ever go to the primary.
+func (p *endpointPool) order(method string, preferred string) []*poolEndpoint {
+	if len(p.endpoints) == 1 {
+		return p.endpoints
+	}
+
+	write := method != http.MethodGet && method != http.MethodHead
+
+	var ordered []*poolEndpoint
+	switch {
+	case p.strategy == strategyPrimaryForWrites && write:
+		return p.endpoints[:1]
+
+	case p.strategy == strategyPrimaryForWrites:
+		// Replicas first, starting at a different one each time, and the
+		// primary as the last resort
+		ordered = rotate(p.endpoints[1:], p.next.Add(1))
+		ordered = append(ordered, p.endpoints[0])
+
+	case p.strategy == strategyRoundRobin:
+		ordered = rotate(p.endpoints, p.next.Add(1))
+
+	default:
+		ordered = append(ordered, p.endpoints...)
+	}
+
+	for i, endpoint := range ordered {
+		if endpoint.url == preferred {
+			copy(ordered[1:i+1], ordered[:i])
+			ordered[0] = endpoint
+			break
+		}
+	}
+	return ordered
+}
+
+// hosts returns the host (and port) of every endpoint.
+func (p *endpointPool) hosts() []string {
+	hosts := make([]string, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		hosts = append(hosts, endpointHost(endpoint.url))
+	}
+	return hosts
+}
+
+// rotate returns a copy of endpoints starting at index n (mod length).
+func rotate(endpoints []*poolEndpoint, n uint64) []*poolEndpoint {
+	start := int(n % uint64(len(endpoints)))
+	rotated := make([]*poolEndpoint, 0, len(endpoints))
+	rotated = append(rotated, endpoints[start:]...)
+	return append(rotated, endpoints[:start]...)
+}
+
+// endpointHost returns the host (and port) of an endpoint URL, or the
+// URL itself if it doesn't parse.
+func endpointHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Host
+}
+
+// notSent reports whether err means the request never reached the
+// server, so even a write can safely be tried on another endpoint.
+// Anything else (a timeout waiting for the response, say) might have
+// been applied already.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// endpointTrackerKey is the context key for an *endpointTracker.
+type endpointTrackerKey struct{}
+
+// endpointTracker carries which endpoint a resource was served by
+// between the resource and DemoAppClient.Do. Resources keep that in
+// private state and hand it back on the next request, so a resource is
+// read from the Demo App it was written to. Within one operation, each
+// request prefers the endpoint that answered the one before, so a write
+// is read back from where it landed.
+type endpointTracker struct {
+	preferred string
+
+	mu     sync.Mutex
+	served string
+}
+
+// withEndpointTracker returns a context for client calls made on behalf
+// of one resource. preferred is the endpoint from private state (empty
+// if none); the returned tracker reports the endpoint that answered.
+func withEndpointTracker(ctx context.Context, preferred string) (context.Context, *endpointTracker) {
+	tracker := &endpointTracker{preferred: preferred}
+	return context.WithValue(ctx, endpointTrackerKey{}, tracker), tracker
+}
+
+// trackerFrom returns the tracker in ctx, or nil.
+func trackerFrom(ctx context.Context) *endpointTracker {
+	tracker, _ := ctx.Value(endpointTrackerKey{}).(*endpointTracker)
+	return tracker
+}
+
+// Served returns the endpoint that answered the last request, falling
+// back to the preferred one if no request was made.
+func (t *endpointTracker) Served() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.served == "" {
+		return t.preferred
+	}
+	return t.served
+}
+
+// serve records the endpoint that answered a request.
+func (t *endpointTracker) serve(endpoint string) {
+	t.mu.Lock()
+	t.served = endpoint
+	t.mu.Unlock()
+}
+
+// requestFor returns req aimed at endpoint instead of the primary.
+// Requests are always built against DemoAppClient.Endpoint, so only the
+// prefix needs swapping.
+func requestFor(req *http.Request, primary, endpoint string, first bool) (*http.Request, error) {
+	rawURL := req.URL.String()
+	if endpoint != primary {
+		if rest, ok := strings.CutPrefix(rawURL, primary); ok {
+			rawURL = endpoint + rest
+		}
+	}
+	if first && rawURL == req.URL.String() {
+		return req, nil
+	}
+
+	// The first attempt still has its body; later ones need a new copy
+	retry := req.Clone(req.Context())
+	if !first {
+		var err error
+		if retry, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+	if rawURL != req.URL.String() {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		retry.URL = u
+		retry.Host = ""
+	}
+	return retry, nil
+}
+
+// rewindRequest clones req with a fresh copy of its body, so it can be
+// sent again after an earlier attempt consumed the body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body can't be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
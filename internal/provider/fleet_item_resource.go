@@ -0,0 +1,475 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ resource.Resource = &FleetItemResource{}
+var _ resource.ResourceWithModifyPlan = &FleetItemResource{}
+
+// FleetItemResource manages the same item on several independent Demo App
+// instances, e.g. one per workshop attendee. Unlike the provider's
+// endpoints setting, which treats several endpoints as one Demo App, each
+// instance here has its own copy of the item with its own ID.
+//
+// Every instance is written in parallel, and a failure on one instance
+// doesn't stop the others: the IDs that were written are kept in state
+// and each failing instance gets its own diagnostic. Instances still
+// missing the item are finished by the next apply.
+type FleetItemResource struct {
+	client *DemoAppClient
+}
+
+// FleetItemResourceModel maps to the Terraform configuration and state.
+type FleetItemResourceModel struct {
+	// ID is a fixed placeholder; the real IDs live in IDs
+	ID types.String `tfsdk:"id"`
+
+	// Endpoints are the base URLs of the Demo App instances
+	Endpoints types.Set `tfsdk:"endpoints"`
+
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+
+	// ShareCredentials sends the provider's token, profile headers and
+	// TLS settings to every instance
+	ShareCredentials types.Bool `tfsdk:"share_credentials"`
+
+	// IDs maps each endpoint to the item ID on that instance
+	IDs types.Map `tfsdk:"ids"`
+}
+
+// fleetResult is the outcome of one operation on one instance.
+type fleetResult struct {
+	endpoint string
+
+	// id is the item ID on the instance afterwards, empty if there is
+	// no item there (deleted, or never created)
+	id string
+
+	// item is the item as returned by the instance, if it was read or written
+	item *itemAPIModel
+
+	err error
+}
+
+// NewFleetItemResource is the factory function.
+func NewFleetItemResource() resource.Resource {
+	return &FleetItemResource{}
+}
+
+// Metadata sets the resource type name: demoapp_fleet_item
+func (r *FleetItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fleet_item"
+}
+
+// Schema defines what users can configure.
+func (r *FleetItemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the same item on every Demo App instance in a fleet, e.g. one instance per workshop attendee. Instances are written in parallel, and failures are reported per instance.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder ID (always 'fleet_item').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"endpoints": schema.SetAttribute{
+				Description: "Base URLs of the Demo App instances to manage the item on (e.g., http://attendee-1:8080). These are independent of the provider's endpoint. Adding an endpoint creates the item there; removing one deletes it there.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				Description: "The name of the item.",
+				Required:    true,
+				Validators:  itemNameValidators(),
+			},
+
+			"description": schema.StringAttribute{
+				Description: "A description of the item.",
+				Optional:    true,
+				Validators:  itemDescriptionValidators(),
+			},
+
+			"share_credentials": schema.BoolAttribute{
+				Description: "When true, the provider's token, profile headers and TLS settings are sent to every instance in endpoints. Only enable this when the instances are trusted with those credentials. Defaults to false: instances are called without authentication.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+
+			"ids": schema.MapAttribute{
+				Description: "The item ID on each instance, keyed by endpoint. An instance the item couldn't be written to is missing until a later apply succeeds.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (r *FleetItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan keeps ids known when every endpoint already has the item,
+// and forces an update when some don't. That second case covers an
+// instance that failed during the last apply, or lost the item since:
+// nothing in the configuration changed, but the item still needs writing.
+func (r *FleetItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state FleetItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Endpoints.IsUnknown() {
+		return
+	}
+
+	var endpoints []string
+	resp.Diagnostics.Append(plan.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	ids, diags := state.ids(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inSync := len(endpoints) == len(ids)
+	for _, endpoint := range endpoints {
+		if _, ok := ids[endpoint]; !ok {
+			inSync = false
+		}
+	}
+
+	if inSync {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ids"), state.IDs)...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ids"), types.MapUnknown(types.StringType))...)
+}
+
+// Create creates the item on every instance.
+func (r *FleetItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FleetItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var endpoints []string
+	resp.Diagnostics.Append(plan.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write the item everywhere. Create only fails if no instance got the
+	// item: an error would make Terraform taint the resource and recreate
+	// it on every instance. Otherwise the failures become warnings, and
+	// since ids is missing those instances, the next plan (see ModifyPlan)
+	// finishes the job without touching the rest.
+	ids, diags := r.sync(ctx, map[string]string{}, endpoints, plan, true)
+	if len(ids) == 0 {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(fleetWarnings(diags)...)
+
+	plan.ID = types.StringValue("fleet_item")
+	resp.Diagnostics.Append(plan.setIDs(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the item from every instance in parallel.
+func (r *FleetItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FleetItemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, diags := state.ids(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := r.forEach(ctx, sortedKeys(ids), state.ShareCredentials.ValueBool(), func(client *DemoAppClient, endpoint string) fleetResult {
+		item, err := client.GetItem(ctx, ids[endpoint])
+		if errors.Is(err, errNotFound) {
+			return fleetResult{endpoint: endpoint}
+		}
+		return fleetResult{endpoint: endpoint, id: ids[endpoint], item: item, err: err}
+	})
+
+	drifted := false
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			// One unreachable attendee shouldn't block the plan for
+			// everyone else, so keep what we knew about that instance
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("endpoints"),
+				"Could Not Refresh Fleet Item",
+				fmt.Sprintf("%s: %s. The previous state for this instance is kept.", result.endpoint, result.err),
+			)
+
+		case result.id == "":
+			// Deleted outside Terraform; ModifyPlan plans to recreate it
+			delete(ids, result.endpoint)
+
+		case !drifted:
+			// State holds one name and description for the whole fleet.
+			// If any instance has been changed, show its values so the
+			// plan brings every instance back in line.
+			name := types.StringValue(result.item.Name)
+			description := descriptionFromAPI(result.item.Description, state.Description)
+			if !name.Equal(state.Name) || !description.Equal(state.Description) {
+				state.Name = name
+				state.Description = description
+				drifted = true
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(state.setIDs(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update writes the new values to every instance, creates the item on
+// instances that don't have it, and deletes it from removed instances.
+func (r *FleetItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state FleetItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var endpoints []string
+	resp.Diagnostics.Append(plan.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	current, diags := state.ids(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Existing items only need a PUT if the values changed
+	changed := !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description)
+
+	ids, diags := r.sync(ctx, current, endpoints, plan, changed)
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue("fleet_item")
+	resp.Diagnostics.Append(plan.setIDs(ctx, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the item from every instance.
+func (r *FleetItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FleetItemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.ids(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Syncing against no endpoints deletes the item everywhere
+	ids, diags := r.sync(ctx, current, nil, state, false)
+	resp.Diagnostics.Append(diags...)
+
+	// If some deletes failed, keep those instances in state
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(state.setIDs(ctx, ids)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
+
+// sync makes every instance match the model: instances in endpoints
+// without the item get it created, instances with it get it updated when
+// write is set, and instances no longer in endpoints get it deleted.
+// All instances are handled in parallel.
+//
+// The returned map is the item ID on each instance afterwards. It is
+// accurate even when some instances failed, and every failure is
+// reported as its own error.
+func (r *FleetItemResource) sync(ctx context.Context, current map[string]string, endpoints []string, m FleetItemResourceModel, write bool) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	planned := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		planned[endpoint] = true
+	}
+
+	all := make(map[string]bool, len(current)+len(planned))
+	for endpoint := range current {
+		all[endpoint] = true
+	}
+	for endpoint := range planned {
+		all[endpoint] = true
+	}
+
+	body := itemAPIModel{
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+	}
+
+	results := r.forEach(ctx, sortedKeys(all), m.ShareCredentials.ValueBool(), func(client *DemoAppClient, endpoint string) fleetResult {
+		id, exists := current[endpoint]
+
+		switch {
+		case !planned[endpoint]:
+			return fleetResult{endpoint: endpoint, err: client.DeleteItem(ctx, id)}
+
+		case !exists:
+			item, err := client.CreateItem(ctx, body)
+			if err != nil {
+				return fleetResult{endpoint: endpoint, err: err}
+			}
+			return fleetResult{endpoint: endpoint, id: strconv.Itoa(item.ID), item: item}
+
+		case write:
+			item, err := client.UpdateItem(ctx, id, body)
+			return fleetResult{endpoint: endpoint, id: id, item: item, err: err}
+		}
+
+		return fleetResult{endpoint: endpoint, id: id}
+	})
+
+	ids := make(map[string]string, len(results))
+	for _, result := range results {
+		id, exists := current[result.endpoint]
+
+		if result.err == nil {
+			if result.id != "" {
+				ids[result.endpoint] = result.id
+			}
+			continue
+		}
+
+		// A failed delete or update leaves the item where it was
+		if exists {
+			ids[result.endpoint] = id
+		}
+
+		action := "Updating"
+		switch {
+		case !planned[result.endpoint]:
+			action = "Deleting"
+		case !exists:
+			action = "Creating"
+		}
+		diags.AddAttributeError(
+			path.Root("endpoints"),
+			"Error "+action+" Fleet Item",
+			fmt.Sprintf("%s: %s", result.endpoint, result.err),
+		)
+	}
+
+	return ids, diags
+}
+
+// fleetWarnings turns the per-instance errors from sync into warnings,
+// for failures the next apply will retry.
+func fleetWarnings(diags diag.Diagnostics) diag.Diagnostics {
+	var warnings diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity() != diag.SeverityError {
+			warnings.Append(d)
+			continue
+		}
+		detail := d.Detail() + ". The item was created on the other instances; the next apply creates it on this one."
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			warnings.AddAttributeWarning(withPath.Path(), d.Summary(), detail)
+		} else {
+			warnings.AddWarning(d.Summary(), detail)
+		}
+	}
+	return warnings
+}
+
+// forEach runs fn for every endpoint in parallel, each with a client for
+// that instance, and returns the results in the order of endpoints.
+// shareCredentials decides whether the clients carry the provider's
+// credentials (see forEndpoint).
+func (r *FleetItemResource) forEach(ctx context.Context, endpoints []string, shareCredentials bool, fn func(client *DemoAppClient, endpoint string) fleetResult) []fleetResult {
+	results := make([]fleetResult, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = fn(r.client.forEndpoint(endpoint, shareCredentials), endpoint)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// ids returns the ids map from state.
+func (m *FleetItemResourceModel) ids(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	ids := make(map[string]string)
+	if m.IDs.IsNull() || m.IDs.IsUnknown() {
+		return ids, nil
+	}
+	diags := m.IDs.ElementsAs(ctx, &ids, false)
+	return ids, diags
+}
+
+// setIDs writes the ids map back into the model.
+func (m *FleetItemResourceModel) setIDs(ctx context.Context, ids map[string]string) diag.Diagnostics {
+	idMap, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	m.IDs = idMap
+	return diags
+}
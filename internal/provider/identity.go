@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkIdentityEndpoint makes sure an import identity's endpoint_host is
+// the Demo App the provider is configured for. Without this, importing
+// with an identity copied from another environment would quietly adopt
+// whatever object has the same ID here. A null endpoint_host (it's
+// optional when importing) always passes.
+func checkIdentityEndpoint(client *DemoAppClient, endpointHost types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if identityEndpointMatches(client, endpointHost) {
+		return diags
+	}
+
+	host := client.EndpointHost()
+	diags.AddError(
+		"Wrong Demo App Instance",
+		fmt.Sprintf("The resource identity belongs to the Demo App at %q, but the provider is configured for %q. "+
+			"Check the provider's endpoint, or remove endpoint_host from the import block to import from %q.",
+			endpointHost.ValueString(), host, host),
+	)
+
+	return diags
+}
+
+// warnIdentityEndpoint is the refresh counterpart of checkIdentityEndpoint.
+// An existing resource whose identity names another host is usually the
+// same Demo App under another name (localhost vs 127.0.0.1, a docker
+// hostname), so this is only a warning. Read then records the provider's
+// current host in the identity, so the warning shows up once.
+func warnIdentityEndpoint(client *DemoAppClient, endpointHost types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if identityEndpointMatches(client, endpointHost) {
+		return diags
+	}
+
+	host := client.EndpointHost()
+	diags.AddWarning(
+		"Demo App Endpoint Changed",
+		fmt.Sprintf("This resource was last managed through the Demo App at %q, but the provider is now configured for %q. "+
+			"The resource is read from %q and its identity is updated to match. "+
+			"If %q is a different Demo App rather than another address for the same one, the object with this ID there is a different object: "+
+			"point the provider back at %q before applying.",
+			endpointHost.ValueString(), host, host, host, endpointHost.ValueString()),
+	)
+
+	return diags
+}
+
+// identityEndpointMatches reports whether endpointHost is null or one of
+// the provider's endpoints. Any of the configured endpoints will do; they
+// serve the same data.
+func identityEndpointMatches(client *DemoAppClient, endpointHost types.String) bool {
+	if endpointHost.IsNull() || endpointHost.IsUnknown() {
+		return true
+	}
+	return slices.Contains(client.EndpointHosts(), endpointHost.ValueString())
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestIdentityEndpoint covers the same identity host on import, where a
+// mismatch is an error, and on refresh, where it is only a warning.
+func TestIdentityEndpoint(t *testing.T) {
+	client := &DemoAppClient{
+		Endpoint:  "http://localhost:8080",
+		endpoints: newEndpointPool([]string{"http://localhost:8080"}, strategyFailover),
+	}
+
+	tests := []struct {
+		name         string
+		endpointHost types.String
+		wantProblem  bool
+	}{
+		{"null", types.StringNull(), false},
+		{"same host", types.StringValue("localhost:8080"), false},
+		{"other alias", types.StringValue("127.0.0.1:8080"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := checkIdentityEndpoint(client, tt.endpointHost)
+			if imported.HasError() != tt.wantProblem || imported.WarningsCount() != 0 {
+				t.Errorf("import: %v, want error = %t", imported, tt.wantProblem)
+			}
+
+			refreshed := warnIdentityEndpoint(client, tt.endpointHost)
+			if refreshed.HasError() || (refreshed.WarningsCount() == 1) != tt.wantProblem {
+				t.Errorf("refresh: %v, want warning = %t", refreshed, tt.wantProblem)
+			}
+		})
+	}
+}
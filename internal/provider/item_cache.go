@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// itemCache is a short-lived snapshot of GET /api/items, enabled by the
+// provider's read_cache_ttl. Refreshing fifty demoapp_item resources
+// would otherwise cost fifty GET /api/items/{id} requests; with the cache
+// the first Read lists every item once and the others are served from
+// memory.
+//
+// Any write through the client drops the snapshot (see DemoAppClient.Do),
+// so a provider never serves data older than its own last change.
+type itemCache struct {
+	// mu is held while fetching, so concurrent Reads at the start of a
+	// refresh wait for one list request instead of each sending their own
+	mu  sync.Mutex
+	ttl time.Duration
+
+	items     []itemAPIModel
+	byID      map[string]itemAPIModel
+	fetchedAt time.Time
+
+	// hits and misses are logged as the cache is used
+	hits   int
+	misses int
+}
+
+// newItemCache returns an empty cache whose snapshots live for ttl.
+func newItemCache(ttl time.Duration) *itemCache {
+	return &itemCache{ttl: ttl}
+}
+
+// snapshot returns the cached item list, fetching a new one with list if
+// there is none or it has expired.
+func (c *itemCache) snapshot(ctx context.Context, list func(context.Context) ([]itemAPIModel, error)) ([]itemAPIModel, map[string]itemAPIModel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byID != nil && time.Since(c.fetchedAt) < c.ttl {
+		c.hits++
+		c.log(ctx, "Item read cache hit")
+		return c.items, c.byID, nil
+	}
+
+	c.misses++
+	c.log(ctx, "Item read cache miss, listing items")
+
+	items, err := list(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.items = items
+	c.byID = make(map[string]itemAPIModel, len(items))
+	for _, item := range items {
+		c.byID[strconv.Itoa(item.ID)] = item
+	}
+	c.fetchedAt = time.Now()
+
+	return c.items, c.byID, nil
+}
+
+// invalidate drops the snapshot, so the next read lists items again.
+func (c *itemCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = nil
+	c.byID = nil
+}
+
+// log writes a debug line with the running hit/miss counts. Callers hold mu.
+func (c *itemCache) log(ctx context.Context, msg string) {
+	tflog.Debug(ctx, msg, map[string]any{
+		"cache_hits":     c.hits,
+		"cache_misses":   c.misses,
+		"cache_hit_rate": float64(c.hits) / float64(c.hits+c.misses),
+	})
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ list.ListResource = &ItemListResource{}
+var _ list.ListResourceWithConfigure = &ItemListResource{}
+
+// itemListPageSize is how many items are requested per page.
+const itemListPageSize = 100
+
+// ItemListResource lists existing items for `terraform query` (Terraform
+// 1.14+). Each result carries the item's identity and, when asked for, its
+// full demoapp_item attributes, so `terraform query -generate-config-out`
+// can write import blocks and resource configuration for an existing demo.
+type ItemListResource struct {
+	client *DemoAppClient
+}
+
+// ItemListResourceModel maps to the list block's configuration.
+type ItemListResourceModel struct {
+	// NamePrefix only lists items whose name starts with it
+	NamePrefix types.String `tfsdk:"name_prefix"`
+
+	// Tag only lists items that have it
+	Tag types.String `tfsdk:"tag"`
+}
+
+// NewItemListResource is the factory function.
+func NewItemListResource() list.ListResource {
+	return &ItemListResource{}
+}
+
+// Metadata sets the type name. A list resource has the same name as the
+// resource it lists: demoapp_item
+func (r *ItemListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item"
+}
+
+// ListResourceConfigSchema defines the filters users can configure.
+func (r *ItemListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists items in Demo App, optionally filtered by name prefix or tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list items whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"tag": schema.StringAttribute{
+				Description: "Only list items with this tag.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (r *ItemListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// List pages through GET /api/items and streams every matching item.
+func (r *ItemListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	// 1. Read the filters
+	var config ItemListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// 2. Stream results page by page. Results are produced lazily, as
+	// Terraform asks for them, so an early stop skips the remaining pages.
+	stream.Results = func(push func(list.ListResult) bool) {
+		// Demo App versions without paging return the full list for every
+		// page; seen stops us from listing items twice and looping forever
+		seen := make(map[int]bool)
+		var listed int64
+
+		for offset := 0; ; offset += itemListPageSize {
+			page, err := r.client.ListItemsPage(ctx, offset, itemListPageSize)
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError(
+					"Error Listing Items",
+					"Could not list items: "+err.Error(),
+				)
+				push(result)
+				return
+			}
+
+			newItems := 0
+			for _, item := range page {
+				if seen[item.ID] {
+					continue
+				}
+				seen[item.ID] = true
+				newItems++
+
+				if !config.matches(item) {
+					continue
+				}
+
+				if !push(r.result(ctx, req, item)) {
+					return
+				}
+
+				listed++
+				if req.Limit > 0 && listed >= req.Limit {
+					return
+				}
+			}
+
+			// A short page, or one with nothing new, is the last one
+			if len(page) < itemListPageSize || newItems == 0 {
+				return
+			}
+		}
+	}
+}
+
+// result builds the list result for one item.
+func (r *ItemListResource) result(ctx context.Context, req list.ListRequest, item itemAPIModel) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = item.Name
+
+	id := strconv.Itoa(item.ID)
+	result.Diagnostics.Append(result.Identity.Set(ctx, itemIdentity(r.client, id))...)
+
+	// The full object is only needed when generating configuration
+	if req.IncludeResource {
+		var model ItemResourceModel
+		result.Diagnostics.Append(model.fromAPI(ctx, item)...)
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	}
+
+	return result
+}
+
+// matches reports whether an item passes the configured filters.
+func (m ItemListResourceModel) matches(item itemAPIModel) bool {
+	if prefix := m.NamePrefix.ValueString(); prefix != "" && !strings.HasPrefix(item.Name, prefix) {
+		return false
+	}
+	if tag := m.Tag.ValueString(); tag != "" && !slices.Contains(item.Tags, tag) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package provider
+
+import "sync"
+
+// itemNameRegistry remembers which item names have been claimed while
+// Terraform plans the current configuration.
+//
+// Terraform starts a fresh provider process for every plan/apply walk and
+// plans each resource once per walk, so a name that shows up twice in the
+// registry means two demoapp_item blocks in the same configuration want it.
+// The registry lives on the DemoAppClient so every ItemResource shares it.
+type itemNameRegistry struct {
+	mu sync.Mutex
+
+	// owners maps an item name to the ID of the item that claimed it.
+	// Items that don't exist yet claim with an empty ID.
+	owners map[string]string
+}
+
+// newItemNameRegistry returns an empty registry.
+func newItemNameRegistry() *itemNameRegistry {
+	return &itemNameRegistry{
+		owners: make(map[string]string),
+	}
+}
+
+// claim records that the item with the given ID (empty for new items) uses
+// name. It returns false when some other item in the configuration has
+// already claimed the name.
+func (r *itemNameRegistry) claim(name, id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// An existing item may be planned more than once with the same name;
+	// anything else claiming an owned name is a duplicate
+	owner, ok := r.owners[name]
+	if ok && (id == "" || owner != id) {
+		return false
+	}
+
+	r.owners[name] = id
+	return true
+}
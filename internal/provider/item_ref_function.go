@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time interface check
+var _ function.Function = &ItemRefFunction{}
+
+// ItemRefFunction implements provider::demoapp::item_ref(name).
+// It checks a name against the same rules as demoapp_item's name
+// attribute, so names built in modules fail early with a clear message.
+type ItemRefFunction struct{}
+
+// NewItemRefFunction is the factory function.
+func NewItemRefFunction() function.Function {
+	return &ItemRefFunction{}
+}
+
+// Metadata sets the function name.
+func (f *ItemRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "item_ref"
+}
+
+// Definition describes the parameters and return type.
+func (f *ItemRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate an item name",
+		Description: fmt.Sprintf("Returns name unchanged if it is a valid demoapp_item name (1-%d characters; %s), and fails otherwise.", maxItemNameLength, itemNameCharsetMessage),
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The item name to validate.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the name.
+func (f *ItemRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	// Same limits as itemNameValidators (UTF8LengthBetween), counting
+	// characters rather than bytes so both accept exactly the same names
+	if length := utf8.RuneCountInString(name); length < 1 || length > maxItemNameLength {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Item name must be 1-%d characters, got %d.", maxItemNameLength, length))
+		return
+	}
+	if !itemNameRegexp.MatchString(name) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Item name %q %s.", name, itemNameCharsetMessage))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
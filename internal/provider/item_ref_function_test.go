@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestItemRefMatchesValidators checks that item_ref accepts exactly the
+// names demoapp_item accepts, so a name that passes the function can't
+// fail the resource.
+func TestItemRefMatchesValidators(t *testing.T) {
+	names := []string{
+		"Web Server",
+		"",
+		"-web",
+		strings.Repeat("a", maxItemNameLength),
+		strings.Repeat("a", maxItemNameLength+1),
+		strings.Repeat("服", 50),
+		strings.Repeat("服", maxItemNameLength),
+		strings.Repeat("服", maxItemNameLength+1),
+		"Café Ünïcode",
+	}
+
+	for _, name := range names {
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(name)}),
+		}
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+		NewItemRefFunction().Run(context.Background(), req, &resp)
+
+		accepted := resp.Error == nil
+		if want := validateString(itemNameValidators(), name); accepted != want {
+			t.Errorf("item_ref(%q) accepted = %t, but itemNameValidators valid = %t", name, accepted, want)
+		}
+	}
+}
@@ -4,20 +4,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Compile-time check: does ItemResource implement resource.Resource?
 var _ resource.Resource = &ItemResource{}
+var _ resource.ResourceWithModifyPlan = &ItemResource{}
+var _ resource.ResourceWithImportState = &ItemResource{}
+var _ resource.ResourceWithIdentity = &ItemResource{}
+var _ resource.ResourceWithUpgradeState = &ItemResource{}
+var _ resource.ResourceWithMoveState = &ItemResource{}
 
 // ItemResource defines the resource implementation.
 type ItemResource struct {
@@ -33,6 +45,19 @@ type ItemResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
+	Tags        types.Set    `tfsdk:"tags"`
+	Metadata    types.Map    `tfsdk:"metadata"`
+	Status      types.String `tfsdk:"status"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+// itemIdentityModel is the resource identity of an item: which Demo App
+// it lives in, and its ID there. Terraform 1.12+ uses it for import blocks
+// and terraform query.
+type itemIdentityModel struct {
+	EndpointHost types.String `tfsdk:"endpoint_host"`
+	ItemID       types.String `tfsdk:"item_id"`
 }
 
 // itemAPIModel represents the JSON structure from the demo-app API.
@@ -41,9 +66,96 @@ type ItemResourceModel struct {
 //   - API might have fields we don't expose to Terraform
 //   - Keeps API concerns separate from Terraform concerns
 type itemAPIModel struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	UpdatedAt   string            `json:"updated_at,omitempty"`
+
+	// Extra holds any fields the API returned that this struct doesn't know
+	// about. They are sent back as-is on update, so a newer demo-app doesn't
+	// lose data just because the provider is older.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// itemStatuses are the values accepted for the status attribute.
+var itemStatuses = []string{"provisioning", "healthy", "degraded"}
+
+// itemPrivateKey is the private state key holding itemPrivateState.
+const itemPrivateKey = "item_api"
+
+// itemPrivateState is what ItemResource keeps in private state, as JSON.
+// Users never see it, but it survives between Terraform runs.
+type itemPrivateState struct {
+	// Extensions are values we sent for fields the API didn't return
+	// (e.g. a demo-app version without tags). Read fills them back in
+	// so those attributes round-trip instead of showing a diff.
+	Extensions itemExtensions `json:"extensions"`
+
+	// UnknownFields are the API fields from itemAPIModel.Extra,
+	// replayed on update
+	UnknownFields map[string]json.RawMessage `json:"unknown_fields,omitempty"`
+
+	// Endpoint is the Demo App endpoint that last served the item, tried
+	// first next time when the provider has several endpoints
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// itemExtensions holds the optional item fields the API may not support.
+type itemExtensions struct {
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Status   string            `json:"status,omitempty"`
+}
+
+// MarshalJSON writes the known fields plus anything in Extra.
+// Known fields win if Extra has the same key.
+func (m itemAPIModel) MarshalJSON() ([]byte, error) {
+	// plain has the same fields but no methods, so this doesn't recurse
+	type plain itemAPIModel
+	body, err := json.Marshal(plain(m))
+	if err != nil || len(m.Extra) == 0 {
+		return body, err
+	}
+
+	var known map[string]json.RawMessage
+	if err := json.Unmarshal(body, &known); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]json.RawMessage, len(known)+len(m.Extra))
+	for k, v := range m.Extra {
+		merged[k] = v
+	}
+	for k, v := range known {
+		merged[k] = v
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON reads the known fields and collects the rest into Extra.
+func (m *itemAPIModel) UnmarshalJSON(data []byte) error {
+	type plain itemAPIModel
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, known := range []string{"id", "name", "description", "tags", "metadata", "status", "created_at", "updated_at"} {
+		delete(fields, known)
+	}
+
+	m.Extra = nil
+	if len(fields) > 0 {
+		m.Extra = fields
+	}
+	return nil
 }
 
 // NewItemResource is the factory function that creates instances of this resource.
@@ -54,6 +166,26 @@ func NewItemResource() resource.Resource {
 // Metadata sets the resource type name.
 func (r *ItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_item"
+
+	// endpoint_host follows the provider's endpoint, so it changes when
+	// the same Demo App is reached under another name
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// IdentitySchema defines the resource identity.
+func (r *ItemResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"endpoint_host": identityschema.StringAttribute{
+				Description:       "Host (and port) of the Demo App the item lives in, e.g. localhost:8080.",
+				OptionalForImport: true,
+			},
+			"item_id": identityschema.StringAttribute{
+				Description:       "The Demo App ID of the item.",
+				RequiredForImport: true,
+			},
+		},
+	}
 }
 
 // Schema defines the structure of the resource.
@@ -61,6 +193,9 @@ func (r *ItemResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Description: "Manages an item in the Demo App.",
 
+		// Version 1 stores an omitted description as null rather than ""
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the item.",
@@ -73,16 +208,158 @@ func (r *ItemResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"name": schema.StringAttribute{
 				Description: "The name of the item.",
 				Required:    true,
+				Validators:  itemNameValidators(),
 			},
 
 			"description": schema.StringAttribute{
 				Description: "A description of the item.",
 				Optional:    true,
+				Validators:  itemDescriptionValidators(),
+			},
+
+			"tags": schema.SetAttribute{
+				Description: "A set of tags for the item.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"metadata": schema.MapAttribute{
+				Description: "Arbitrary key/value metadata for the item.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"status": schema.StringAttribute{
+				Description: "The status of the item: provisioning, healthy or degraded.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(itemStatuses...),
+				},
+				// When status isn't configured, an update keeps the
+				// current one rather than showing "known after apply"
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"created_at": schema.StringAttribute{
+				Description: "When the item was created, as reported by Demo App.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"updated_at": schema.StringAttribute{
+				Description: "When the item was last updated, as reported by Demo App.",
+				Computed:    true,
 			},
 		},
 	}
 }
 
+// UpgradeState migrates state written by earlier provider versions.
+func (r *ItemResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeItemStateV0},
+	}
+}
+
+// upgradeItemStateV0 converts a version 0 item state. Early versions
+// stored an omitted description as "", which now means "explicitly
+// empty"; it becomes null.
+func upgradeItemStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	raw, err := decodeRawState(req.RawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Item State",
+			err.Error(),
+		)
+		return
+	}
+
+	state := ItemResourceModel{
+		ID:          raw.String("id"),
+		Name:        raw.String("name"),
+		Description: raw.String("description"),
+		Status:      raw.String("status"),
+		CreatedAt:   raw.String("created_at"),
+		UpdatedAt:   raw.String("updated_at"),
+		Tags:        types.SetNull(types.StringType),
+		Metadata:    types.MapNull(types.StringType),
+	}
+	if state.Description.ValueString() == "" {
+		state.Description = types.StringNull()
+	}
+
+	var tags []string
+	if raw.Decode("tags", &tags) {
+		value, diags := types.SetValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(diags...)
+		state.Tags = value
+	}
+
+	var metadata map[string]string
+	if raw.Decode("metadata", &metadata) {
+		value, diags := types.MapValueFrom(ctx, types.StringType, metadata)
+		resp.Diagnostics.Append(diags...)
+		state.Metadata = value
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// MoveState lets a moved block turn a restapi_object that was POSTed to
+// /api/items into a demoapp_item, without destroying and recreating it.
+func (r *ItemResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveFromRestapiObject},
+	}
+}
+
+// moveFromRestapiObject converts a restapi_object state. Only what the
+// restapi state records is copied; the next refresh fills in the rest.
+func (r *ItemResource) moveFromRestapiObject(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	object, err := readRestapiObject(req)
+	if object == nil && err == nil {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Moving Item",
+			err.Error(),
+		)
+		return
+	}
+
+	if object.Path != "/api/items" {
+		resp.Diagnostics.AddError(
+			"Error Moving Item",
+			fmt.Sprintf("Only restapi_object resources with path \"/api/items\" can be moved to demoapp_item, got %q.", object.Path),
+		)
+		return
+	}
+
+	item, err := object.item()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Moving Item",
+			err.Error(),
+		)
+		return
+	}
+
+	// Starting from an empty model, an empty description becomes null
+	var state ItemResourceModel
+	resp.Diagnostics.Append(state.fromAPI(ctx, item)...)
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &state)...)
+
+	if r.client != nil && resp.TargetIdentity != nil {
+		resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, itemIdentity(r.client, state.ID.ValueString()))...)
+	}
+}
+
 // Configure receives the provider's configured client.
 func (r *ItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Provider hasn't been configured yet
@@ -105,6 +382,72 @@ func (r *ItemResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan rejects names that are already taken when the provider has
+// enforce_unique_item_names turned on.
+func (r *ItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	// (e.g. during terraform validate)
+	if req.Plan.Raw.IsNull() || r.client == nil || !r.client.EnforceUniqueItemNames {
+		return
+	}
+
+	var plan ItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Names that depend on other resources aren't known until apply
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		return
+	}
+	name := plan.Name.ValueString()
+
+	// The ID of the item this resource already manages, if any
+	var id string
+	if !req.State.Raw.IsNull() {
+		var state ItemResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		id = state.ID.ValueString()
+	}
+
+	// 1. Check for duplicates within this configuration
+	if !r.client.itemNames.claim(name, id) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Duplicate Item Name",
+			fmt.Sprintf("Another demoapp_item in this configuration already uses the name %q. "+
+				"Item names must be unique while enforce_unique_item_names is enabled.", name),
+		)
+		return
+	}
+
+	// 2. Check the live inventory for items this resource doesn't manage
+	items, err := r.client.ListItems(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Checking Item Names",
+			"Could not list items: "+err.Error(),
+		)
+		return
+	}
+
+	for _, item := range items {
+		if item.Name == name && strconv.Itoa(item.ID) != id {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Item Name Already In Use",
+				fmt.Sprintf("Demo App already has an item named %q (ID %d) that is not managed by this resource. "+
+					"Item names must be unique while enforce_unique_item_names is enabled.", name, item.ID),
+			)
+			return
+		}
+	}
+}
+
 // Create makes a POST request to create a new item.
 func (r *ItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// 1. Read the planned values from Terraform configuration
@@ -116,9 +459,10 @@ func (r *ItemResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// 2. Build the request body
 	// We convert from Terraform types to plain Go types for JSON encoding
-	requestBody := itemAPIModel{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	requestBody, diags := plan.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	jsonBody, err := json.Marshal(requestBody)
@@ -130,7 +474,8 @@ func (r *ItemResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// 3. Make the HTTP request
+	// 3. Make the HTTP request, noting which endpoint handles it
+	ctx, served := withEndpointTracker(ctx, "")
 	url := r.client.Endpoint + "/api/items"
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
@@ -142,7 +487,7 @@ func (r *ItemResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
+	httpResp, err := r.client.Do(httpReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Item",
@@ -174,12 +519,17 @@ func (r *ItemResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// 6. Update the plan with values from the API response
 	// The API gives us the ID, which we need to store in state
-	plan.ID = types.StringValue(strconv.Itoa(apiResponse.ID))
-	plan.Name = types.StringValue(apiResponse.Name)
-	plan.Description = types.StringValue(apiResponse.Description)
+	private := itemPrivateState{
+		Extensions:    unsupportedFields(requestBody, apiResponse),
+		UnknownFields: apiResponse.Extra,
+		Endpoint:      served.Served(),
+	}
+	resp.Diagnostics.Append(plan.fromAPI(ctx, private.Extensions.fill(apiResponse))...)
 
-	// 7. Save the state
+	// 7. Save the state and identity
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, itemIdentity(r.client, plan.ID.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, itemPrivateKey, private.encode())...)
 }
 
 // Read fetches the current state from the API.
@@ -191,29 +541,29 @@ func (r *ItemResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// 2. Make the HTTP request
-	url := r.client.Endpoint + "/api/items/" + state.ID.ValueString()
-	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Item",
-			"Could not create HTTP request: "+err.Error(),
-		)
-		return
+	// Warn if the identity names another Demo App host. It's usually the
+	// same Demo App under another name, so the read goes ahead
+	if req.Identity != nil && !req.Identity.Raw.IsNull() {
+		var identity itemIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(warnIdentityEndpoint(r.client, identity.EndpointHost)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Item",
-			"Could not send HTTP request: "+err.Error(),
-		)
-		return
-	}
-	defer httpResp.Body.Close()
+	// 2. Fetch the item, from the endpoint that served it last time. With
+	// read_cache_ttl set, this is served from a snapshot of the whole item
+	// list rather than one request per item.
+	rawPrivate, diags := req.Private.GetKey(ctx, itemPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	private := decodeItemPrivateState(rawPrivate)
+
+	ctx, served := withEndpointTracker(ctx, private.Endpoint)
+	item, err := r.client.GetItem(ctx, state.ID.ValueString())
 
 	// 3. Handle 404 - resource was deleted outside Terraform
-	if httpResp.StatusCode == http.StatusNotFound {
+	if errors.Is(err, errNotFound) {
 		// Tell Terraform the resource no longer exists
 		// This will show as "will be created" in the next plan
 		resp.State.RemoveResource(ctx)
@@ -221,32 +571,25 @@ func (r *ItemResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// 4. Check for other errors
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		resp.Diagnostics.AddError(
-			"Error Reading Item",
-			fmt.Sprintf("API returned status %d: %s", httpResp.StatusCode, string(body)),
-		)
-		return
-	}
-
-	// 5. Parse the response
-	var apiResponse itemAPIModel
-	if err := json.NewDecoder(httpResp.Body).Decode(&apiResponse); err != nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Item",
-			"Could not parse API response: "+err.Error(),
+			err.Error(),
 		)
 		return
 	}
 
-	// 6. Update state with current values from API
-	state.ID = types.StringValue(strconv.Itoa(apiResponse.ID))
-	state.Name = types.StringValue(apiResponse.Name)
-	state.Description = types.StringValue(apiResponse.Description)
+	// 5. Update state with current values from API
+	// Fields the API doesn't return come from private state
+	private.UnknownFields = item.Extra
+	private.Endpoint = served.Served()
+	resp.Diagnostics.Append(state.fromAPI(ctx, private.Extensions.fill(*item))...)
 
-	// 7. Save the refreshed state
+	// 6. Save the refreshed state and identity. Identity is set on every
+	// read so items created before identity support get one too.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, itemIdentity(r.client, state.ID.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, itemPrivateKey, private.encode())...)
 }
 
 // Update makes a PUT request to update an existing item.
@@ -259,10 +602,16 @@ func (r *ItemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// 2. Build the request body
-	requestBody := itemAPIModel{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	// Unknown fields from the last read are sent back so they aren't dropped
+	requestBody, diags := plan.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	rawPrivate, diags := req.Private.GetKey(ctx, itemPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	prior := decodeItemPrivateState(rawPrivate)
+	requestBody.Extra = prior.UnknownFields
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
@@ -273,7 +622,8 @@ func (r *ItemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// 3. Make the HTTP request
+	// 3. Make the HTTP request, preferring the endpoint the item came from
+	ctx, served := withEndpointTracker(ctx, prior.Endpoint)
 	url := r.client.Endpoint + "/api/items/" + plan.ID.ValueString()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
@@ -285,7 +635,7 @@ func (r *ItemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
+	httpResp, err := r.client.Do(httpReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Item",
@@ -316,12 +666,40 @@ func (r *ItemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// 6. Update plan with values from API response
-	plan.ID = types.StringValue(strconv.Itoa(apiResponse.ID))
-	plan.Name = types.StringValue(apiResponse.Name)
-	plan.Description = types.StringValue(apiResponse.Description)
+	private := itemPrivateState{
+		Extensions:    unsupportedFields(requestBody, apiResponse),
+		UnknownFields: apiResponse.Extra,
+		Endpoint:      served.Served(),
+	}
+	resp.Diagnostics.Append(plan.fromAPI(ctx, private.Extensions.fill(apiResponse))...)
 
-	// 7. Save the updated state
+	// 7. Save the updated state and identity
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, itemIdentity(r.client, plan.ID.ValueString()))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, itemPrivateKey, private.encode())...)
+}
+
+// ImportState imports an existing item by its Demo App ID, given either
+// as the import ID or as item_id in an import block's identity.
+// Only the ID and identity are set here; Read fills in the rest. Because
+// the imported description starts out null, an empty description imports
+// as null too.
+func (r *ItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if id == "" {
+		var identity itemIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(checkIdentityEndpoint(r.client, identity.EndpointHost)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		id = identity.ItemID.ValueString()
+	}
+
+	// Set the complete identity now, so an omitted endpoint_host doesn't
+	// look like an identity change when Read fills it in
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, itemIdentity(r.client, id))...)
 }
 
 // Delete makes a DELETE request to remove an item.
@@ -333,7 +711,11 @@ func (r *ItemResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// 2. Make the HTTP request
+	// 2. Make the HTTP request, preferring the endpoint the item came from
+	rawPrivate, diags := req.Private.GetKey(ctx, itemPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	ctx, _ = withEndpointTracker(ctx, decodeItemPrivateState(rawPrivate).Endpoint)
+
 	url := r.client.Endpoint + "/api/items/" + state.ID.ValueString()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
@@ -344,7 +726,7 @@ func (r *ItemResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	httpResp, err := r.client.HTTPClient.Do(httpReq)
+	httpResp, err := r.client.Do(httpReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Item",
@@ -366,3 +748,141 @@ func (r *ItemResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	// 4. Terraform automatically removes from state after Delete returns successfully
 }
+
+// toAPI converts the Terraform model into an API request body.
+func (m *ItemResourceModel) toAPI(ctx context.Context) (itemAPIModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	item := itemAPIModel{
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+		Status:      m.Status.ValueString(),
+	}
+
+	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
+		diags.Append(m.Tags.ElementsAs(ctx, &item.Tags, false)...)
+		sort.Strings(item.Tags)
+	}
+
+	if !m.Metadata.IsNull() && !m.Metadata.IsUnknown() {
+		diags.Append(m.Metadata.ElementsAs(ctx, &item.Metadata, false)...)
+	}
+
+	return item, diags
+}
+
+// fromAPI copies an API item into the model.
+// The API has no notion of null: an omitted description comes back as "",
+// and missing tags or metadata come back empty. In each case the attribute
+// keeps the null-or-empty form it already had in the model (plan or prior
+// state), so leaving it out of the configuration stays null and setting it
+// to "" or [] stays empty.
+func (m *ItemResourceModel) fromAPI(ctx context.Context, item itemAPIModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(strconv.Itoa(item.ID))
+	m.Name = types.StringValue(item.Name)
+	m.Description = descriptionFromAPI(item.Description, m.Description)
+	m.Status = stringOrNull(item.Status)
+	m.CreatedAt = stringOrNull(item.CreatedAt)
+	m.UpdatedAt = stringOrNull(item.UpdatedAt)
+
+	// SetValueFrom and MapValueFrom turn a nil slice or map into null.
+	// When the model already has a value, nil from the API (or from
+	// private state, which drops empty values) means empty, not null.
+	if len(item.Tags) > 0 || (!m.Tags.IsNull() && !m.Tags.IsUnknown()) {
+		if item.Tags == nil {
+			item.Tags = []string{}
+		}
+		tags, d := types.SetValueFrom(ctx, types.StringType, item.Tags)
+		diags.Append(d...)
+		m.Tags = tags
+	} else {
+		m.Tags = types.SetNull(types.StringType)
+	}
+
+	if len(item.Metadata) > 0 || (!m.Metadata.IsNull() && !m.Metadata.IsUnknown()) {
+		if item.Metadata == nil {
+			item.Metadata = map[string]string{}
+		}
+		metadata, d := types.MapValueFrom(ctx, types.StringType, item.Metadata)
+		diags.Append(d...)
+		m.Metadata = metadata
+	} else {
+		m.Metadata = types.MapNull(types.StringType)
+	}
+
+	return diags
+}
+
+// unsupportedFields returns the optional fields that were sent but not
+// returned by the API, which means this demo-app doesn't store them.
+func unsupportedFields(sent, got itemAPIModel) itemExtensions {
+	var ext itemExtensions
+	if len(got.Tags) == 0 {
+		ext.Tags = sent.Tags
+	}
+	if len(got.Metadata) == 0 {
+		ext.Metadata = sent.Metadata
+	}
+	if got.Status == "" {
+		ext.Status = sent.Status
+	}
+	return ext
+}
+
+// fill returns item with any empty optional fields taken from the extensions.
+func (e itemExtensions) fill(item itemAPIModel) itemAPIModel {
+	if len(item.Tags) == 0 {
+		item.Tags = e.Tags
+	}
+	if len(item.Metadata) == 0 {
+		item.Metadata = e.Metadata
+	}
+	if item.Status == "" {
+		item.Status = e.Status
+	}
+	return item
+}
+
+// decodeItemPrivateState parses private state written by encode.
+// Missing or unreadable data just means there is nothing to round-trip.
+func decodeItemPrivateState(data []byte) itemPrivateState {
+	var private itemPrivateState
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &private)
+	}
+	return private
+}
+
+// encode serializes the private state for SetKey.
+func (p itemPrivateState) encode() []byte {
+	data, _ := json.Marshal(p)
+	return data
+}
+
+// descriptionFromAPI maps the API's empty description to null when the
+// prior value was null. Writing "" into state for an omitted description
+// makes Terraform report "Provider produced inconsistent result".
+func descriptionFromAPI(description string, prior types.String) types.String {
+	if description == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(description)
+}
+
+// itemIdentity returns the identity of item id in the client's Demo App.
+func itemIdentity(client *DemoAppClient, id string) itemIdentityModel {
+	return itemIdentityModel{
+		EndpointHost: types.StringValue(client.EndpointHost()),
+		ItemID:       types.StringValue(id),
+	}
+}
+
+// stringOrNull maps an empty API string to null.
+func stringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
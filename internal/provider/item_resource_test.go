@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDescriptionFromAPI(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		prior       types.String
+		want        types.String
+	}{
+		{"omitted stays null", "", types.StringNull(), types.StringNull()},
+		{"explicitly empty stays empty", "", types.StringValue(""), types.StringValue("")},
+		{"set from null prior", "web", types.StringNull(), types.StringValue("web")},
+		{"set from empty prior", "web", types.StringValue(""), types.StringValue("web")},
+		{"cleared out of band", "", types.StringValue("web"), types.StringValue("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptionFromAPI(tt.description, tt.prior); !got.Equal(tt.want) {
+				t.Errorf("descriptionFromAPI(%q, %s) = %s, want %s", tt.description, tt.prior, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestItemFromAPIDescription runs the description through fromAPI the way
+// each CRUD path does: Create and Update start from the plan, Read from the
+// prior state, and import from a state holding only the ID.
+func TestItemFromAPIDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		model ItemResourceModel
+		api   string
+		want  types.String
+	}{
+		{
+			name:  "create omitted",
+			model: ItemResourceModel{Name: types.StringValue("a"), Description: types.StringNull()},
+			want:  types.StringNull(),
+		},
+		{
+			name:  "create explicitly empty",
+			model: ItemResourceModel{Name: types.StringValue("a"), Description: types.StringValue("")},
+			want:  types.StringValue(""),
+		},
+		{
+			name:  "read omitted",
+			model: ItemResourceModel{ID: types.StringValue("1"), Name: types.StringValue("a"), Description: types.StringNull()},
+			want:  types.StringNull(),
+		},
+		{
+			name:  "read explicitly empty",
+			model: ItemResourceModel{ID: types.StringValue("1"), Name: types.StringValue("a"), Description: types.StringValue("")},
+			want:  types.StringValue(""),
+		},
+		{
+			name:  "update to omitted",
+			model: ItemResourceModel{ID: types.StringValue("1"), Name: types.StringValue("a"), Description: types.StringNull()},
+			want:  types.StringNull(),
+		},
+		{
+			name:  "update to explicitly empty",
+			model: ItemResourceModel{ID: types.StringValue("1"), Name: types.StringValue("a"), Description: types.StringValue("")},
+			want:  types.StringValue(""),
+		},
+		{
+			name:  "import empty",
+			model: ItemResourceModel{ID: types.StringValue("1")},
+			want:  types.StringNull(),
+		},
+		{
+			name:  "import set",
+			model: ItemResourceModel{ID: types.StringValue("1")},
+			api:   "web",
+			want:  types.StringValue("web"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model
+			m.Tags = types.SetNull(types.StringType)
+			m.Metadata = types.MapNull(types.StringType)
+
+			diags := m.fromAPI(context.Background(), itemAPIModel{ID: 1, Name: "a", Description: tt.api})
+			if diags.HasError() {
+				t.Fatalf("fromAPI: %v", diags)
+			}
+			if !m.Description.Equal(tt.want) {
+				t.Errorf("description = %s, want %s", m.Description, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryFromAPI(t *testing.T) {
+	tests := []struct {
+		name  string
+		prior types.String
+		api   string
+		want  types.String
+	}{
+		{"omitted", types.StringNull(), "", types.StringNull()},
+		{"explicitly empty", types.StringValue(""), "", types.StringValue("")},
+		{"set", types.StringNull(), "primary", types.StringValue("primary")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := itemsEntryModel{Name: types.StringValue("db"), Description: tt.prior}
+			got := entryFromAPI(itemAPIModel{Name: "db", Description: tt.api}, prior)
+			if !got.Description.Equal(tt.want) {
+				t.Errorf("description = %s, want %s", got.Description, tt.want)
+			}
+			if got.Name.ValueString() != "db" {
+				t.Errorf("name = %s, want db", got.Name)
+			}
+		})
+	}
+}
+
+// TestItemFromAPIEmptyCollections covers tags = [] and metadata = {}
+// against a demo-app that doesn't return those fields: they must stay
+// empty rather than turning null, which would show a diff on every plan.
+func TestItemFromAPIEmptyCollections(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		tags         types.Set
+		metadata     types.Map
+		wantTagsNull bool
+		wantMetaNull bool
+	}{
+		{
+			name:         "omitted",
+			tags:         types.SetNull(types.StringType),
+			metadata:     types.MapNull(types.StringType),
+			wantTagsNull: true,
+			wantMetaNull: true,
+		},
+		{
+			name:     "explicitly empty",
+			tags:     types.SetValueMust(types.StringType, []attr.Value{}),
+			metadata: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ItemResourceModel{
+				ID:          types.StringValue("1"),
+				Name:        types.StringValue("a"),
+				Description: types.StringNull(),
+				Tags:        tt.tags,
+				Metadata:    tt.metadata,
+			}
+
+			// The API returns neither field, and private state has nothing
+			// to fill in because empty values are omitted from it
+			private := decodeItemPrivateState(itemPrivateState{
+				Extensions: itemExtensions{Tags: []string{}, Metadata: map[string]string{}},
+			}.encode())
+			item := private.Extensions.fill(itemAPIModel{ID: 1, Name: "a"})
+
+			if diags := m.fromAPI(ctx, item); diags.HasError() {
+				t.Fatalf("fromAPI: %v", diags)
+			}
+
+			if m.Tags.IsNull() != tt.wantTagsNull || len(m.Tags.Elements()) != 0 {
+				t.Errorf("tags = %s, want null = %t", m.Tags, tt.wantTagsNull)
+			}
+			if m.Metadata.IsNull() != tt.wantMetaNull || len(m.Metadata.Elements()) != 0 {
+				t.Errorf("metadata = %s, want null = %t", m.Metadata, tt.wantMetaNull)
+			}
+		})
+	}
+}
@@ -0,0 +1,505 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ resource.Resource = &ItemsResource{}
+var _ resource.ResourceWithModifyPlan = &ItemsResource{}
+
+// ItemsResource manages a whole set of items from a single map.
+// Where demoapp_item needs one resource (and one API call per plan/apply step)
+// for every item, demoapp_items works out the minimal set of creates, updates
+// and deletes and runs them one after another in a single operation.
+type ItemsResource struct {
+	client *DemoAppClient
+}
+
+// ItemsResourceModel maps to the Terraform configuration and state.
+type ItemsResourceModel struct {
+	// ID is a fixed placeholder; the real IDs live in IDs
+	ID types.String `tfsdk:"id"`
+
+	// Items maps a user-chosen key to an itemsEntryModel
+	Items types.Map `tfsdk:"items"`
+
+	// IDs maps the same keys to the Demo App item IDs
+	IDs types.Map `tfsdk:"ids"`
+
+	// Exclusive deletes any items in Demo App that aren't in Items
+	Exclusive types.Bool `tfsdk:"exclusive"`
+
+	// UnmanagedIDs are the items found by the last refresh that exclusive
+	// mode will delete; always empty when exclusive is off
+	UnmanagedIDs types.Set `tfsdk:"unmanaged_ids"`
+}
+
+// itemsEntryModel is one value of the items map.
+type itemsEntryModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// managedItem is an item this resource has written to Demo App,
+// remembered together with its key and API ID.
+type managedItem struct {
+	ID    string
+	Entry itemsEntryModel
+}
+
+// itemsEntryAttrTypes describes itemsEntryModel for building map values.
+var itemsEntryAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"description": types.StringType,
+}
+
+// NewItemsResource is the factory function.
+func NewItemsResource() resource.Resource {
+	return &ItemsResource{}
+}
+
+// Metadata sets the resource type name: demoapp_items
+func (r *ItemsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_items"
+}
+
+// Schema defines what users can configure.
+func (r *ItemsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of items in Demo App from a single map. Changes are applied as the minimal set of creates, updates and deletes in one operation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder ID (always 'items').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"items": schema.MapNestedAttribute{
+				Description: "Items to manage, keyed by a name of your choosing. Keys are only used by Terraform to track items between runs.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the item.",
+							Required:    true,
+							Validators:  itemNameValidators(),
+						},
+						"description": schema.StringAttribute{
+							Description: "A description of the item.",
+							Optional:    true,
+							Validators:  itemDescriptionValidators(),
+						},
+					},
+				},
+			},
+
+			"ids": schema.MapAttribute{
+				Description: "The Demo App ID of each item, keyed like items.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+
+			"exclusive": schema.BoolAttribute{
+				Description: "When true, items in Demo App that are not in items are deleted, including items added outside Terraform since the last apply. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+
+			"unmanaged_ids": schema.SetAttribute{
+				Description: "With exclusive set, the IDs of items found in Demo App that are not in items. Any found here show up as drift, and the next apply deletes them. Always empty after an apply.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (r *ItemsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan keeps ids known in the plan when no keys are added or removed.
+// Without this, changing a single description would show every ID as
+// "known after apply".
+//
+// It also plans unmanaged_ids as empty, since every apply leaves it that
+// way. When Read found unmanaged items in exclusive mode, that is a diff,
+// so the next apply runs an update that deletes them.
+func (r *ItemsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_ids"), emptyIDSet())...)
+
+	// Nothing more to do on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ItemsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Items.IsUnknown() || state.IDs.IsNull() {
+		return
+	}
+
+	planItems := plan.Items.Elements()
+	stateIDs := state.IDs.Elements()
+	if len(planItems) != len(stateIDs) {
+		return
+	}
+	for key := range planItems {
+		if _, ok := stateIDs[key]; !ok {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ids"), state.IDs)...)
+}
+
+// Create creates every item in the map.
+func (r *ItemsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ItemsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := make(map[string]itemsEntryModel)
+	resp.Diagnostics.Append(plan.Items.ElementsAs(ctx, &planned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Apply the changes. On error we still save whatever was created so
+	// those items aren't orphaned.
+	managed, diags := r.sync(ctx, map[string]managedItem{}, planned, plan.Exclusive.ValueBool())
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue("items")
+	plan.UnmanagedIDs = emptyIDSet()
+	resp.Diagnostics.Append(plan.setManaged(ctx, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes all managed items with a single list request.
+func (r *ItemsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ItemsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := state.managed(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := r.client.ListItems(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Items",
+			"Could not list items: "+err.Error(),
+		)
+		return
+	}
+
+	live := make(map[string]itemAPIModel, len(items))
+	for _, item := range items {
+		live[strconv.Itoa(item.ID)] = item
+	}
+
+	// Items deleted outside Terraform drop out of state,
+	// so the next plan shows them as being created again
+	for key, m := range managed {
+		item, ok := live[m.ID]
+		if !ok {
+			delete(managed, key)
+			continue
+		}
+		managed[key] = managedItem{ID: m.ID, Entry: entryFromAPI(item, m.Entry)}
+	}
+
+	// In exclusive mode, anything else in Demo App is drift: recording it
+	// makes the next plan show an update, which deletes it
+	unmanaged := []string{}
+	if state.Exclusive.ValueBool() {
+		keep := make(map[string]bool, len(managed))
+		for _, m := range managed {
+			keep[m.ID] = true
+		}
+		for _, id := range sortedKeys(live) {
+			if !keep[id] {
+				unmanaged = append(unmanaged, id)
+			}
+		}
+	}
+	unmanagedIDs, diags := types.SetValueFrom(ctx, types.StringType, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	state.UnmanagedIDs = unmanagedIDs
+
+	resp.Diagnostics.Append(state.setManaged(ctx, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update applies the difference between the managed items and the plan.
+func (r *ItemsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ItemsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := make(map[string]itemsEntryModel)
+	resp.Diagnostics.Append(plan.Items.ElementsAs(ctx, &planned, false)...)
+	current, diags := state.managed(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := r.sync(ctx, current, planned, plan.Exclusive.ValueBool())
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue("items")
+	plan.UnmanagedIDs = emptyIDSet()
+	resp.Diagnostics.Append(plan.setManaged(ctx, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes every managed item.
+func (r *ItemsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ItemsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.managed(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Syncing against an empty map deletes everything we manage.
+	// Exclusive is ignored here: destroying this resource shouldn't
+	// touch items it never created.
+	managed, diags := r.sync(ctx, current, map[string]itemsEntryModel{}, false)
+	resp.Diagnostics.Append(diags...)
+
+	// If some deletes failed, keep the survivors in state
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(state.setManaged(ctx, managed)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
+
+// sync makes Demo App match the planned items, starting from the items
+// currently managed. Keys missing from planned are deleted, new keys are
+// created and changed entries are updated; unchanged entries cost no API
+// calls at all. Operations run one after another, which also keeps
+// demo-app's SQLite backend from seeing concurrent writes.
+//
+// The returned map is what is actually managed afterwards. It is accurate
+// even when an error stops sync part way through.
+func (r *ItemsResource) sync(ctx context.Context, current map[string]managedItem, planned map[string]itemsEntryModel, exclusive bool) (map[string]managedItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	managed := make(map[string]managedItem, len(current))
+	for key, m := range current {
+		managed[key] = m
+	}
+
+	// 1. Delete keys that were removed from the map
+	for _, key := range sortedKeys(current) {
+		if _, ok := planned[key]; ok {
+			continue
+		}
+		if err := r.client.DeleteItem(ctx, current[key].ID); err != nil {
+			diags.AddError(
+				"Error Deleting Item",
+				fmt.Sprintf("Could not delete item %q (ID %s): %s", key, current[key].ID, err),
+			)
+			return managed, diags
+		}
+		delete(managed, key)
+	}
+
+	// 2. Create new keys and update changed ones
+	for _, key := range sortedKeys(planned) {
+		entry := planned[key]
+		body := itemAPIModel{
+			Name:        entry.Name.ValueString(),
+			Description: entry.Description.ValueString(),
+		}
+
+		existing, ok := current[key]
+		if ok && existing.Entry.Name.Equal(entry.Name) && existing.Entry.Description.Equal(entry.Description) {
+			continue
+		}
+
+		var item *itemAPIModel
+		var err error
+		if ok {
+			item, err = r.client.UpdateItem(ctx, existing.ID, body)
+		} else {
+			item, err = r.client.CreateItem(ctx, body)
+		}
+		if err != nil {
+			action := "Creating"
+			if ok {
+				action = "Updating"
+			}
+			diags.AddError(
+				"Error "+action+" Item",
+				fmt.Sprintf("Could not write item %q: %s", key, err),
+			)
+			return managed, diags
+		}
+
+		managed[key] = managedItem{ID: strconv.Itoa(item.ID), Entry: entryFromAPI(*item, entry)}
+	}
+
+	// 3. In exclusive mode, remove everything else
+	if !exclusive {
+		return managed, diags
+	}
+
+	keep := make(map[string]bool, len(managed))
+	for _, m := range managed {
+		keep[m.ID] = true
+	}
+
+	items, err := r.client.ListItems(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Deleting Unmanaged Items",
+			"Could not list items: "+err.Error(),
+		)
+		return managed, diags
+	}
+
+	for _, item := range items {
+		id := strconv.Itoa(item.ID)
+		if keep[id] {
+			continue
+		}
+		if err := r.client.DeleteItem(ctx, id); err != nil {
+			diags.AddError(
+				"Error Deleting Unmanaged Items",
+				fmt.Sprintf("Could not delete item %q (ID %s): %s", item.Name, id, err),
+			)
+			return managed, diags
+		}
+	}
+
+	return managed, diags
+}
+
+// managed pairs up the items and ids maps from state.
+func (m *ItemsResourceModel) managed(ctx context.Context) (map[string]managedItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entries := make(map[string]itemsEntryModel)
+	ids := make(map[string]string)
+	if !m.Items.IsNull() {
+		diags.Append(m.Items.ElementsAs(ctx, &entries, false)...)
+	}
+	if !m.IDs.IsNull() {
+		diags.Append(m.IDs.ElementsAs(ctx, &ids, false)...)
+	}
+
+	managed := make(map[string]managedItem, len(ids))
+	for key, id := range ids {
+		managed[key] = managedItem{ID: id, Entry: entries[key]}
+	}
+
+	return managed, diags
+}
+
+// setManaged writes managed items back into the items and ids maps.
+func (m *ItemsResourceModel) setManaged(ctx context.Context, managed map[string]managedItem) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entries := make(map[string]itemsEntryModel, len(managed))
+	ids := make(map[string]string, len(managed))
+	for key, item := range managed {
+		entries[key] = item.Entry
+		ids[key] = item.ID
+	}
+
+	items, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: itemsEntryAttrTypes}, entries)
+	diags.Append(d...)
+	idMap, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+
+	m.Items = items
+	m.IDs = idMap
+	return diags
+}
+
+// emptyIDSet is the value of unmanaged_ids after every apply.
+func emptyIDSet() types.Set {
+	return types.SetValueMust(types.StringType, []attr.Value{})
+}
+
+// entryFromAPI converts an API item to a map entry. An empty description
+// stays null when the previous entry had none, so omitting description
+// doesn't produce a diff.
+func entryFromAPI(item itemAPIModel, prior itemsEntryModel) itemsEntryModel {
+	return itemsEntryModel{
+		Name:        types.StringValue(item.Name),
+		Description: descriptionFromAPI(item.Description, prior.Description),
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order, so API calls happen in
+// a predictable sequence.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
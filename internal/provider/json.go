@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// canonicalJSON re-encodes a JSON document with sorted object keys, no
+// insignificant whitespace and numbers in canonical form. Two documents
+// that mean the same thing produce the same canonical bytes, which is
+// what we compare and hash.
+func canonicalJSON(data []byte) ([]byte, error) {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(value)
+}
+
+// jsonencodeForm returns data in the form Terraform's jsonencode produces:
+// canonical JSON with <, > and & (and U+2028, U+2029) escaped as \u003c,
+// \u003e, \u0026 and so on. State that should match a jsonencode() in the
+// configuration uses this form, so it doesn't show a diff.
+func jsonencodeForm(data []byte) ([]byte, error) {
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	json.HTMLEscape(&buf, canonical)
+	return buf.Bytes(), nil
+}
+
+// decodeJSON parses a single JSON document into plain Go values
+// (map[string]any, []any, json.Number, string, bool, nil). Numbers are
+// converted to canonical form, see canonicalNumber.
+func decodeJSON(data []byte) (any, error) {
+	// UseNumber keeps numbers exact instead of going via float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return canonicalNumbers(value), nil
+}
+
+// canonicalNumbers replaces every number in value with its canonical form.
+func canonicalNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = canonicalNumbers(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = canonicalNumbers(child)
+		}
+	case json.Number:
+		return canonicalNumber(v)
+	}
+	return value
+}
+
+// canonicalNumber writes n the way a backend that re-serializes numbers
+// would, so 1.0, 1e0 and 1 all become 1. Integers are written in full,
+// however large, so IDs beyond float64 precision stay distinct. Other
+// numbers go via float64 and are written in its shortest form without an
+// exponent, the way Terraform's jsonencode writes them (1e-7 becomes
+// 0.0000001).
+func canonicalNumber(n json.Number) json.Number {
+	var r big.Rat
+	if _, ok := r.SetString(n.String()); !ok {
+		return n
+	}
+	if r.IsInt() {
+		return json.Number(r.Num().String())
+	}
+
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		return n
+	}
+	return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// encodeJSON encodes a value from decodeJSON in canonical form.
+func encodeJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonEqual reports whether a and b are semantically the same JSON.
+// Invalid JSON is never equal to anything.
+func jsonEqual(a, b []byte) bool {
+	ca, err := canonicalJSON(a)
+	if err != nil {
+		return false
+	}
+	cb, err := canonicalJSON(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ca, cb)
+}
+
+// jsonSHA256 returns the hex SHA-256 of the canonical form of data,
+// so formatting differences don't change the hash.
+func jsonSHA256(data []byte) (string, error) {
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// mergePatch applies patch to target as a JSON Merge Patch (RFC 7386):
+// objects are merged recursively, null in the patch removes a key, and
+// any other patch value replaces the target value outright.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	merged := make(map[string]any, len(targetObject))
+	for key, value := range targetObject {
+		merged[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = mergePatch(merged[key], value)
+	}
+	return merged
+}
+
+// jsonDiff returns the JSON pointers (RFC 6901) of every value that differs
+// between a and b. Objects are compared key by key (in sorted order) and
+// arrays index by index; anything else is compared as a whole. Identical
+// documents have no differences; entirely different ones differ at "".
+func jsonDiff(a, b any) []string {
+	var pointers []string
+	collectJSONDiff("", a, b, &pointers)
+	return pointers
+}
+
+// collectJSONDiff appends the differences below pointer to pointers.
+func collectJSONDiff(pointer string, a, b any, pointers *[]string) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool, len(av)+len(bv))
+		for key := range av {
+			keys[key] = true
+		}
+		for key := range bv {
+			keys[key] = true
+		}
+		for _, key := range sortedKeys(keys) {
+			child := pointer + "/" + escapeJSONPointer(key)
+			aChild, aOK := av[key]
+			bChild, bOK := bv[key]
+			if aOK != bOK {
+				*pointers = append(*pointers, child)
+				continue
+			}
+			collectJSONDiff(child, aChild, bChild, pointers)
+		}
+		return
+
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			child := pointer + "/" + strconv.Itoa(i)
+			if i >= len(av) || i >= len(bv) {
+				*pointers = append(*pointers, child)
+				continue
+			}
+			collectJSONDiff(child, av[i], bv[i], pointers)
+		}
+		return
+	}
+
+	// Scalars, or values of different types
+	ca, errA := encodeJSON(a)
+	cb, errB := encodeJSON(b)
+	if errA != nil || errB != nil || !bytes.Equal(ca, cb) {
+		*pointers = append(*pointers, pointer)
+	}
+}
+
+// escapeJSONPointer escapes one reference token of a JSON pointer.
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface check
+var _ function.Function = &JSONDiffFunction{}
+
+// JSONDiffFunction implements provider::demoapp::json_diff(a, b).
+// It lists where two JSON documents differ, e.g. to show what a display
+// change will actually touch.
+type JSONDiffFunction struct{}
+
+// NewJSONDiffFunction is the factory function.
+func NewJSONDiffFunction() function.Function {
+	return &JSONDiffFunction{}
+}
+
+// Metadata sets the function name.
+func (f *JSONDiffFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_diff"
+}
+
+// Definition describes the parameters and return type.
+func (f *JSONDiffFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "List the JSON pointers that differ between two documents",
+		Description: "Compares two JSON documents and returns the JSON pointers (RFC 6901) of every value that was added, removed or changed. Objects are compared key by key and arrays index by index. Returns an empty list for equivalent documents, and [\"\"] when the top-level values differ in type.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "The original JSON document.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "The changed JSON document.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run compares the documents.
+func (f *JSONDiffFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	left, err := decodeJSON([]byte(a))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+	right, err := decodeJSON([]byte(b))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+
+	// Return [] rather than null for no differences
+	pointers := jsonDiff(left, right)
+	if pointers == nil {
+		pointers = []string{}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, pointers))
+}
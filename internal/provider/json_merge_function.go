@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time interface check
+var _ function.Function = &JSONMergeFunction{}
+
+// JSONMergeFunction implements provider::demoapp::json_merge(a, b).
+// It deep-merges two JSON documents, so display payloads can be built up
+// from pieces contributed by different modules.
+type JSONMergeFunction struct{}
+
+// NewJSONMergeFunction is the factory function.
+func NewJSONMergeFunction() function.Function {
+	return &JSONMergeFunction{}
+}
+
+// Metadata sets the function name.
+func (f *JSONMergeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_merge"
+}
+
+// Definition describes the parameters and return type.
+func (f *JSONMergeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Deep-merge two JSON documents",
+		Description: "Applies b to a as a JSON Merge Patch (RFC 7386): objects are merged recursively, a null in b removes the key, and any other value in b replaces the value in a. Returns canonical JSON.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "The base JSON document.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "The JSON document merged on top of a.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run merges the documents.
+func (f *JSONMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	target, err := decodeJSON([]byte(a))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+	patch, err := decodeJSON([]byte(b))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+
+	merged, err := encodeJSON(mergePatch(target, patch))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not encode merged JSON: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(merged)))
+}
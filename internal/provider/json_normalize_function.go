@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time interface check
+var _ function.Function = &JSONNormalizeFunction{}
+
+// JSONNormalizeFunction implements provider::demoapp::json_normalize(s).
+// It returns the canonical form of a JSON document, the same form
+// demoapp_display uses to compare and hash content.
+type JSONNormalizeFunction struct{}
+
+// NewJSONNormalizeFunction is the factory function.
+func NewJSONNormalizeFunction() function.Function {
+	return &JSONNormalizeFunction{}
+}
+
+// Metadata sets the function name.
+func (f *JSONNormalizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_normalize"
+}
+
+// Definition describes the parameters and return type.
+func (f *JSONNormalizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Return canonical JSON",
+		Description: "Re-encodes a JSON document with object keys sorted and no insignificant whitespace. Numbers are written in canonical form, so 1.0 and 1e0 both become 1.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "s",
+				Description: "The JSON document to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the document.
+func (f *JSONNormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	canonical, err := canonicalJSON([]byte(s))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(canonical)))
+}
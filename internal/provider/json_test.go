@@ -0,0 +1,53 @@
+package provider
+
+import "testing"
+
+func TestJSONEqualNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"trailing zero", `{"n":1.0}`, `{"n":1}`, true},
+		{"exponent", `{"n":1e2}`, `{"n":100}`, true},
+		{"fraction exponent", `[2.5E-1]`, `[0.25]`, true},
+		{"negative zero", `-0`, `0`, true},
+		{"large integers stay exact", `12345678901234567890`, `12345678901234567891`, false},
+		{"different numbers", `{"n":1.5}`, `{"n":1}`, false},
+		{"number vs string", `{"n":1}`, `{"n":"1"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonEqual([]byte(tt.a), []byte(tt.b)); got != tt.want {
+				t.Errorf("jsonEqual(%s, %s) = %t, want %t", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONNumbers(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"b":1.0,"a":1e2}`, `{"a":100,"b":1}`},
+		{`[0.10, 1.5e0, 1e-7]`, `[0.1,1.5,0.0000001]`},
+		{`12345678901234567890.0`, `12345678901234567890`},
+	}
+
+	for _, tt := range tests {
+		got, err := canonicalJSON([]byte(tt.in))
+		if err != nil {
+			t.Fatalf("canonicalJSON(%s): %v", tt.in, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("canonicalJSON(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	hashA, _ := jsonSHA256([]byte(`{"n":1.0}`))
+	hashB, _ := jsonSHA256([]byte(`{"n":1}`))
+	if hashA != hashB {
+		t.Errorf("jsonSHA256 differs for 1.0 and 1: %s vs %s", hashA, hashB)
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultProfilesFile is where profiles are read from unless config_file
+// says otherwise, relative to the user's home directory.
+const defaultProfilesFile = ".demoapp/config.yaml"
+
+// profilesFile is the layout of the profiles file:
+//
+//	profiles:
+//	  local:
+//	    endpoint: http://localhost:8080
+//	  customer-demo:
+//	    endpoint: https://demo.customer.example.com
+//	    token: s3cr3t
+//	    headers:
+//	      X-Demo-Tenant: acme
+//	    tls:
+//	      ca_file: /etc/ssl/customer-ca.pem
+type profilesFile struct {
+	Profiles map[string]demoAppProfile `yaml:"profiles"`
+}
+
+// demoAppProfile is one named set of connection settings. Anything set
+// in the provider block or the environment takes priority over it.
+type demoAppProfile struct {
+	Endpoint string `yaml:"endpoint"`
+	Token    string `yaml:"token"`
+
+	// Headers are sent with every request, e.g. for an ingress that
+	// routes on a tenant header
+	Headers map[string]string `yaml:"headers"`
+
+	TLS profileTLS `yaml:"tls"`
+}
+
+// profileTLS holds the TLS settings of a profile, for Demo Apps behind a
+// private CA or requiring client certificates.
+type profileTLS struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots
+	CAFile string `yaml:"ca_file"`
+
+	// CertFile and KeyFile are a client certificate and its key
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+
+	// InsecureSkipVerify turns off certificate checks altogether
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+}
+
+// loadProfile reads the named profile from file, or from the default
+// profiles file when file is empty. Problems are reported against the
+// profile or config_file attribute.
+func loadProfile(file, name string) (demoAppProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("config_file"),
+				"Cannot Locate Profiles File",
+				"Could not find the home directory to read "+filepath.Join("~", defaultProfilesFile)+" from: "+err.Error()+
+					". Set config_file to the profiles file instead.",
+			)
+			return demoAppProfile{}, diags
+		}
+		file = filepath.Join(home, defaultProfilesFile)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		detail := fmt.Sprintf("Could not read %s: %s", file, err)
+		if errors.Is(err, fs.ErrNotExist) {
+			detail = fmt.Sprintf("Profile %q was requested, but the profiles file %s does not exist.", name, file)
+		}
+		diags.AddAttributeError(path.Root("config_file"), "Cannot Read Profiles File", detail)
+		return demoAppProfile{}, diags
+	}
+
+	// Unknown keys are rejected, so a typo like "endpont" is an error
+	// instead of a silently missing setting
+	var parsed profilesFile
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&parsed); err != nil {
+		diags.AddAttributeError(
+			path.Root("config_file"),
+			"Invalid Profiles File",
+			fmt.Sprintf("Could not parse %s: %s", file, err),
+		)
+		return demoAppProfile{}, diags
+	}
+
+	profile, ok := parsed.Profiles[name]
+	if !ok {
+		available := "none"
+		if len(parsed.Profiles) > 0 {
+			available = strings.Join(sortedKeys(parsed.Profiles), ", ")
+		}
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Demo App Profile",
+			fmt.Sprintf("Profile %q is not defined in %s. Available profiles: %s.", name, file, available),
+		)
+		return demoAppProfile{}, diags
+	}
+
+	return profile, diags
+}
+
+// config builds the TLS configuration for the profile, or returns nil if
+// the profile has no TLS settings and Go's defaults apply.
+func (t profileTLS) config() (*tls.Config, error) {
+	if t == (profileTLS{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca_file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_file %s contains no PEM certificates", t.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		if t.CertFile == "" || t.KeyFile == "" {
+			return nil, errors.New("cert_file and key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -2,19 +2,34 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the provider.Provider interface.
 var _ provider.Provider = &DemoAppProvider{}
+var _ provider.ProviderWithFunctions = &DemoAppProvider{}
+var _ provider.ProviderWithEphemeralResources = &DemoAppProvider{}
+var _ provider.ProviderWithListResources = &DemoAppProvider{}
 
 // DemoAppClient is the client that resources will use to talk to the demo-app API.
 // We create this in Configure() and pass it to all resources.
@@ -22,8 +37,46 @@ type DemoAppClient struct {
 	// HTTPClient is the underlying HTTP client
 	HTTPClient *http.Client
 
-	// Endpoint is the base URL of the demo-app API (e.g., "http://localhost:8080")
+	// Endpoint is the base URL of the demo-app API (e.g., "http://localhost:8080").
+	// With several endpoints configured this is the primary; requests are
+	// built against it and Do redirects them to the endpoint it picks.
 	Endpoint string
+
+	// Token is sent as a bearer token on every request when set
+	Token string
+
+	// Headers are extra headers sent with every request, from the profile
+	Headers map[string]string
+
+	// Version is the provider version, exposed to display templates
+	Version string
+
+	// EnforceUniqueItemNames makes demoapp_item reject names that are
+	// already in use when planning
+	EnforceUniqueItemNames bool
+
+	// itemNames tracks item names claimed during the current plan so that
+	// duplicates within one configuration can be detected
+	itemNames *itemNameRegistry
+
+	// displayHistory records what demoapp_display wrote to each panel
+	displayHistory *displayHistory
+
+	// itemCache serves item reads from a snapshot of the item list;
+	// nil unless read_cache_ttl is set
+	itemCache *itemCache
+
+	// rateLimiter paces requests; nil unless requests_per_second is set
+	rateLimiter *rateLimiter
+
+	// endpoints picks the Demo App endpoint for each request and tracks
+	// their health, each with its own circuit breaker
+	endpoints *endpointPool
+
+	// instances are the clients handed out by forEndpoint, so each Demo
+	// App instance in a fleet keeps its own health and rate limit
+	instancesMu sync.Mutex
+	instances   map[fleetInstance]*DemoAppClient
 }
 
 // DemoAppProvider defines the provider implementation.
@@ -36,7 +89,17 @@ type DemoAppProvider struct {
 // DemoAppProviderModel describes the provider data model.
 // This maps to the provider block in HCL.
 type DemoAppProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint               types.String  `tfsdk:"endpoint"`
+	Endpoints              types.List    `tfsdk:"endpoints"`
+	Strategy               types.String  `tfsdk:"strategy"`
+	Token                  types.String  `tfsdk:"token"`
+	Profile                types.String  `tfsdk:"profile"`
+	ConfigFile             types.String  `tfsdk:"config_file"`
+	EnforceUniqueItemNames types.Bool    `tfsdk:"enforce_unique_item_names"`
+	DisplayHistoryFile     types.String  `tfsdk:"display_history_file"`
+	ReadCacheTTL           types.String  `tfsdk:"read_cache_ttl"`
+	RequestsPerSecond      types.Float64 `tfsdk:"requests_per_second"`
+	Burst                  types.Int64   `tfsdk:"burst"`
 }
 
 // New is a helper function to simplify provider server construction.
@@ -64,6 +127,62 @@ func (p *DemoAppProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description: "The endpoint URL of the Demo App API (e.g., http://localhost:8080). Can also be set via DEMOAPP_ENDPOINT environment variable.",
 				Optional:    true,
 			},
+			"endpoints": schema.ListAttribute{
+				Description: "Several Demo App endpoints serving the same data (e.g. one per region), used instead of endpoint. The first is the primary. See strategy for how requests are spread across them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ConflictsWith(path.MatchRoot("endpoint")),
+				},
+			},
+			"strategy": schema.StringAttribute{
+				Description: "How requests are spread across endpoints: failover (the first endpoint that is up), round_robin (all endpoints in turn) or primary_for_writes (writes to the first endpoint, reads from the others). Defaults to failover.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(endpointStrategies...),
+					stringvalidator.AlsoRequires(path.MatchRoot("endpoints")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of a profile in the profiles file to take the endpoint, token, TLS settings and headers from. Settings in the provider block or environment variables take priority over the profile. Can also be set via DEMOAPP_PROFILE environment variable.",
+				Optional:    true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path of the profiles file. Defaults to ~/.demoapp/config.yaml.",
+				Optional:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Bearer token sent with every request, for Demo App deployments with auth in front of them. Typically the token of a demoapp_session ephemeral resource. Can also be set via DEMOAPP_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"enforce_unique_item_names": schema.BoolAttribute{
+				Description: "When true, demoapp_item fails at plan time if its name is already used by another item in Demo App or in the same configuration. Defaults to false.",
+				Optional:    true,
+			},
+			"display_history_file": schema.StringAttribute{
+				Description: "Path of the local file where the provider keeps the history of display content, used by rollback_to and the demoapp_display_history data source. Revisions are kept per endpoint host and panel, so several Demo Apps can share the file. Defaults to .terraform/demoapp-display-history.json.",
+				Optional:    true,
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				Description: "How long a snapshot of the item list may serve item reads, as a Go duration (e.g. \"30s\"). When set, refreshing many items costs one GET /api/items instead of one request per item. Any write through the provider drops the snapshot. Disabled by default.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average rate of requests to Demo App, shared by all resources using this provider. Each 429 response halves the rate (down to a tenth of this value) and the request is retried after Retry-After. Unlimited by default.",
+				Optional:    true,
+			},
+			"burst": schema.Int64Attribute{
+				Description: "How many requests may be sent at once before requests_per_second kicks in. Defaults to requests_per_second rounded up.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("requests_per_second")),
+				},
+			},
 		},
 	}
 }
@@ -80,47 +199,168 @@ func (p *DemoAppProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	// Determine the endpoint: HCL config takes priority, then environment variable
+	// A named profile from the profiles file supplies defaults for
+	// everything below. The profile name itself follows the usual
+	// precedence: HCL config, then environment variable.
+	profileName := os.Getenv("DEMOAPP_PROFILE")
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+	}
+
+	var profile demoAppProfile
+	if profileName != "" {
+		var diags diag.Diagnostics
+		profile, diags = loadProfile(config.ConfigFile.ValueString(), profileName)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Determine the endpoint: HCL config takes priority, then environment
+	// variable, then the profile.
 	// This is a common pattern - let users set via provider block OR environment
-	endpoint := os.Getenv("DEMOAPP_ENDPOINT")
+	endpoint := profile.Endpoint
+	if env := os.Getenv("DEMOAPP_ENDPOINT"); env != "" {
+		endpoint = env
+	}
 
 	// If endpoint is set in HCL config, use that instead
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
 	}
 
+	// An endpoints list replaces endpoint; the first one is the primary
+	var endpoints []string
+	if !config.Endpoints.IsNull() {
+		resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		endpoint = endpoints[0]
+	}
+
 	// If we still don't have an endpoint, that's an error
 	if endpoint == "" {
 		resp.Diagnostics.AddError(
 			"Missing Demo App Endpoint",
 			"The provider cannot create the Demo App API client because the endpoint is missing. "+
-				"Set the endpoint in the provider configuration or via the DEMOAPP_ENDPOINT environment variable.",
+				"Set the endpoint in the provider configuration, via the DEMOAPP_ENDPOINT environment variable, or in a profile.",
 		)
 		return
 	}
 
+	if len(endpoints) == 0 {
+		endpoints = []string{endpoint}
+	}
+
+	strategy := strategyFailover
+	if !config.Strategy.IsNull() {
+		strategy = config.Strategy.ValueString()
+	}
+
+	// Same precedence for the token: HCL config, environment variable, profile.
+	// No token at all is fine - Demo App doesn't require auth by default.
+	token := profile.Token
+	if env := os.Getenv("DEMOAPP_TOKEN"); env != "" {
+		token = env
+	}
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	// Display history lives next to Terraform's own local data by default
+	historyFile := defaultDisplayHistoryFile
+	if !config.DisplayHistoryFile.IsNull() {
+		historyFile = config.DisplayHistoryFile.ValueString()
+	}
+
+	// The read cache is off unless a TTL is given
+	var cache *itemCache
+	if !config.ReadCacheTTL.IsNull() {
+		ttl, err := time.ParseDuration(config.ReadCacheTTL.ValueString())
+		if err != nil || ttl < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_cache_ttl"),
+				"Invalid Read Cache TTL",
+				fmt.Sprintf("Expected a non-negative duration such as \"30s\" or \"2m\", got: %q", config.ReadCacheTTL.ValueString()),
+			)
+			return
+		}
+		if ttl > 0 {
+			cache = newItemCache(ttl)
+		}
+	}
+
+	// Requests are only paced when a rate is given
+	var limiter *rateLimiter
+	if !config.RequestsPerSecond.IsNull() {
+		rps := config.RequestsPerSecond.ValueFloat64()
+		if rps <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Requests Per Second",
+				fmt.Sprintf("Expected a rate greater than 0, got: %g", rps),
+			)
+			return
+		}
+
+		burst := int(math.Ceil(rps))
+		if !config.Burst.IsNull() {
+			burst = int(config.Burst.ValueInt64())
+		}
+		limiter = newRateLimiter(rps, burst)
+	}
+
 	// Create the HTTP client with reasonable defaults
 	// 30 second timeout prevents hanging forever on network issues
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
 	}
 
+	// Only profiles carry TLS settings; without them Go's defaults apply
+	tlsConfig, err := profile.TLS.config()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Invalid Profile TLS Settings",
+			fmt.Sprintf("Profile %q: %s", profileName, err),
+		)
+		return
+	}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient.Transport = transport
+	}
+
 	// Create our client wrapper
 	client := &DemoAppClient{
-		HTTPClient: httpClient,
-		Endpoint:   endpoint,
+		HTTPClient:             httpClient,
+		Endpoint:               endpoint,
+		Token:                  token,
+		Headers:                profile.Headers,
+		Version:                p.version,
+		EnforceUniqueItemNames: config.EnforceUniqueItemNames.ValueBool(),
+		itemNames:              newItemNameRegistry(),
+		displayHistory:         newDisplayHistory(historyFile, endpointHost(endpoint)),
+		itemCache:              cache,
+		rateLimiter:            limiter,
+		endpoints:              newEndpointPool(endpoints, strategy),
 	}
 
-	// Pass the client to all resources and data sources
+	// Pass the client to everything the provider implements
 	// When a resource's Configure() method is called, it receives this via req.ProviderData
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *DemoAppProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// We'll add data sources here later
+		NewDisplayHistoryDataSource,
 	}
 }
 
@@ -128,6 +368,37 @@ func (p *DemoAppProvider) DataSources(ctx context.Context) []func() datasource.D
 func (p *DemoAppProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewItemResource,
+		NewItemsResource,
+		NewSeedResource,
 		NewDisplayResource,
+		NewFleetItemResource,
+		NewResetResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the
+// provider. Their results are never written to plan or state.
+func (p *DemoAppProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSessionEphemeralResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider,
+// used by terraform query to discover existing objects.
+func (p *DemoAppProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewItemListResource,
+	}
+}
+
+// Functions defines the provider-defined functions, available in
+// Terraform 1.8+ as provider::demoapp::<name>().
+func (p *DemoAppProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewJSONMergeFunction,
+		NewJSONNormalizeFunction,
+		NewJSONDiffFunction,
+		NewItemRefFunction,
 	}
 }
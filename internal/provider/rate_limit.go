@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitRetries is how many times a request rejected with 429 is
+// retried before the 429 is returned to the caller.
+const maxRateLimitRetries = 3
+
+// defaultRetryAfter is how long to wait after a 429 without a usable
+// Retry-After header.
+const defaultRetryAfter = time.Second
+
+// rateLimiter is a token bucket shared by every request the provider
+// sends, set up from the requests_per_second and burst attributes.
+//
+// The configured rate is a ceiling, not a promise: each 429 from Demo App
+// (or the ingress in front of it) halves the rate, down to a tenth of
+// what was configured. It doesn't climb back up; a provider process only
+// lives for one Terraform command, so the next run starts fresh.
+type rateLimiter struct {
+	limiter *rate.Limiter
+
+	// configured is the rate from requests_per_second; backing off
+	// doesn't go below a tenth of it
+	configured rate.Limit
+}
+
+// newRateLimiter returns a limiter allowing rps requests per second on
+// average, with bursts of up to burst requests.
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		limiter:    rate.NewLimiter(rate.Limit(rps), burst),
+		configured: rate.Limit(rps),
+	}
+}
+
+// forInstance returns a new limiter with the configured rate and burst,
+// for a client talking to a different Demo App instance. A nil limiter
+// (no rate limiting) stays nil.
+func (l *rateLimiter) forInstance() *rateLimiter {
+	if l == nil {
+		return nil
+	}
+	return newRateLimiter(float64(l.configured), l.limiter.Burst())
+}
+
+// wait blocks until the bucket has a token for the next request.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// backOff lowers the rate after a 429 and returns how long to wait before
+// retrying, taken from Retry-After when the response has one.
+func (l *rateLimiter) backOff(ctx context.Context, resp *http.Response) time.Duration {
+	limit := max(l.limiter.Limit()/2, l.configured/10)
+	l.limiter.SetLimit(limit)
+
+	delay := retryAfter(resp.Header.Get("Retry-After"))
+	tflog.Debug(ctx, "Demo App rate limited the request, slowing down", map[string]any{
+		"requests_per_second": float64(limit),
+		"retry_after":         delay.String(),
+	})
+	return delay
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return defaultRetryAfter
+}
+
+// doRateLimited sends req through the limiter, retrying 429 responses.
+// Requests with a body can only be retried if the body can be rewound
+// (GetBody), which http.NewRequest sets up for the bytes and strings
+// readers used throughout the client.
+func (c *DemoAppClient) doRateLimited(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		delay := c.rateLimiter.backOff(ctx, resp)
+		if attempt == maxRateLimitRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		// The previous attempt consumed the body, so send a fresh copy
+		retry, err := rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		req = retry
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface check
+var _ resource.Resource = &ResetResource{}
+
+// ResetResource wipes Demo App back to a baseline: every item not listed
+// in exclude_ids is deleted and the display is cleared.
+//
+// It's an action dressed up as a resource. The reset happens on Create,
+// and every argument forces replacement, so changing triggers (or anything
+// else) runs it again. Read and Delete don't touch Demo App at all.
+type ResetResource struct {
+	client *DemoAppClient
+}
+
+// ResetResourceModel maps to the Terraform configuration and state.
+type ResetResourceModel struct {
+	ID types.String `tfsdk:"id"`
+
+	// Triggers are arbitrary values; changing any of them resets again
+	Triggers types.Map `tfsdk:"triggers"`
+
+	// ExcludeIDs are item IDs that survive the reset
+	ExcludeIDs types.Set `tfsdk:"exclude_ids"`
+
+	// ClearDisplay posts {} to the default display panel
+	ClearDisplay types.Bool `tfsdk:"clear_display"`
+
+	// ItemsDeleted is how many items the reset removed
+	ItemsDeleted types.Int64 `tfsdk:"items_deleted"`
+
+	// ItemsKept is how many items were left because of exclude_ids
+	ItemsKept types.Int64 `tfsdk:"items_kept"`
+
+	// ResetAt is when the reset ran (RFC 3339, UTC)
+	ResetAt types.String `tfsdk:"reset_at"`
+}
+
+// NewResetResource is the factory function.
+func NewResetResource() resource.Resource {
+	return &ResetResource{}
+}
+
+// Metadata sets the resource type name: demoapp_reset
+func (r *ResetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reset"
+}
+
+// Schema defines what users can configure.
+func (r *ResetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resets Demo App to a baseline when created: deletes every item not in exclude_ids and clears the display. Change triggers to reset again. Destroying it does nothing to Demo App.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Same as reset_at.",
+				Computed:    true,
+			},
+
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, run the reset again (e.g. a demo date or customer name).",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"exclude_ids": schema.SetAttribute{
+				Description: "IDs of items to keep. Everything else is deleted.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"clear_display": schema.BoolAttribute{
+				Description: "Whether to clear the default display panel as part of the reset. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"items_deleted": schema.Int64Attribute{
+				Description: "Number of items the reset deleted.",
+				Computed:    true,
+			},
+
+			"items_kept": schema.Int64Attribute{
+				Description: "Number of items left in place because they were in exclude_ids.",
+				Computed:    true,
+			},
+
+			"reset_at": schema.StringAttribute{
+				Description: "When the reset ran (RFC 3339, UTC).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (r *ResetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create performs the reset.
+func (r *ResetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// 1. Read the planned values
+	var plan ResetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var excludeIDs []string
+	resp.Diagnostics.Append(plan.ExcludeIDs.ElementsAs(ctx, &excludeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keep := make(map[string]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		keep[id] = true
+	}
+
+	// 2. Delete every item that isn't excluded
+	items, err := r.client.ListItems(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Resetting Demo App",
+			"Could not list items: "+err.Error(),
+		)
+		return
+	}
+
+	var deleted, kept int64
+	for _, item := range items {
+		id := strconv.Itoa(item.ID)
+		if keep[id] {
+			kept++
+			continue
+		}
+		if err := r.client.DeleteItem(ctx, id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resetting Demo App",
+				fmt.Sprintf("Could not delete item %q (ID %s) after deleting %d items: %s", item.Name, id, deleted, err),
+			)
+			return
+		}
+		deleted++
+	}
+
+	// 3. Clear the display
+	if plan.ClearDisplay.ValueBool() {
+		if err := r.client.SetDisplay(ctx, "", "{}"); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resetting Demo App",
+				fmt.Sprintf("Deleted %d items, but could not clear the display: %s", deleted, err),
+			)
+			return
+		}
+	}
+
+	// 4. Record what happened
+	resetAt := time.Now().UTC().Format(time.RFC3339)
+	plan.ID = types.StringValue(resetAt)
+	plan.ResetAt = types.StringValue(resetAt)
+	plan.ItemsDeleted = types.Int64Value(deleted)
+	plan.ItemsKept = types.Int64Value(kept)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the state as-is. A reset is a one-off event; items created
+// afterwards aren't drift.
+func (r *ResetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called in practice: every argument requires replacement.
+// It's required by the interface, so it just stores the plan.
+func (r *ResetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ResetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.ItemsDeleted = state.ItemsDeleted
+	plan.ItemsKept = state.ItemsKept
+	plan.ResetAt = state.ResetAt
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from state. There's nothing to undo.
+func (r *ResetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// seedFixture is the parsed content of a demoapp_seed source.
+type seedFixture struct {
+	// Items are created in the order they appear in the fixture
+	Items []seedItem `json:"items"`
+
+	// Display is an optional display panel payload (JSON/YAML only)
+	Display json.RawMessage `json:"display,omitempty"`
+}
+
+// seedItem is one item row in a fixture.
+type seedItem struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// loadSeedSource returns the raw fixture content for source.
+// A single line that doesn't look like inline JSON is treated as a file path;
+// anything else is inline content.
+func loadSeedSource(source string) ([]byte, error) {
+	trimmed := strings.TrimSpace(source)
+	if strings.Contains(trimmed, "\n") || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return []byte(source), nil
+	}
+
+	content, err := os.ReadFile(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixture file: %w", err)
+	}
+	return content, nil
+}
+
+// seedContentHash returns the hex SHA-256 of the fixture content.
+func seedContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseSeedFixture decodes fixture content in the given format.
+//
+// JSON and YAML fixtures are either a list of items or an object with an
+// "items" list and an optional "display" payload. CSV fixtures have a
+// header row with a "name" column and an optional "description" column.
+func parseSeedFixture(content []byte, format string) (*seedFixture, error) {
+	switch format {
+	case "json":
+		return parseSeedJSON(content)
+	case "yaml":
+		// Decode the YAML generically and re-encode it as JSON, so both
+		// formats share one set of parsing rules
+		var doc any
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		jsonContent, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert YAML to JSON: %w", err)
+		}
+		return parseSeedJSON(jsonContent)
+	case "csv":
+		return parseSeedCSV(content)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// parseSeedJSON accepts either a bare list of items or a full fixture object.
+func parseSeedJSON(content []byte) (*seedFixture, error) {
+	var fixture seedFixture
+
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &fixture.Items); err != nil {
+			return nil, fmt.Errorf("invalid item list: %w", err)
+		}
+	} else if err := json.Unmarshal(content, &fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+
+	for i, item := range fixture.Items {
+		if item.Name == "" {
+			return nil, fmt.Errorf("item %d has no name", i)
+		}
+		if err := item.validate(); err != nil {
+			return nil, fmt.Errorf("item %d (%q): %w", i, item.Name, err)
+		}
+	}
+
+	return &fixture, nil
+}
+
+// parseSeedCSV reads items from CSV with name/description columns.
+func parseSeedCSV(content []byte) (*seedFixture, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header: %w", err)
+	}
+
+	nameCol, descCol := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "name":
+			nameCol = i
+		case "description":
+			descCol = i
+		}
+	}
+	if nameCol == -1 {
+		return nil, errors.New(`CSV header must have a "name" column`)
+	}
+
+	var fixture seedFixture
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		item := seedItem{Name: strings.TrimSpace(record[nameCol])}
+		if descCol != -1 {
+			item.Description = strings.TrimSpace(record[descCol])
+		}
+		if item.Name == "" {
+			return nil, fmt.Errorf("CSV line %d has no name", line)
+		}
+		if err := item.validate(); err != nil {
+			return nil, fmt.Errorf("CSV line %d (%q): %w", line, item.Name, err)
+		}
+		fixture.Items = append(fixture.Items, item)
+	}
+
+	return &fixture, nil
+}
+
+// validate runs the same checks on a fixture item that demoapp_item runs
+// on its name and description, so a bad fixture fails at plan time rather
+// than with an API error half way through seeding.
+func (item seedItem) validate() error {
+	checks := []struct {
+		attribute  string
+		value      string
+		validators []validator.String
+	}{
+		{"name", item.Name, itemNameValidators()},
+		{"description", item.Description, itemDescriptionValidators()},
+	}
+
+	for _, check := range checks {
+		for _, v := range check.validators {
+			req := validator.StringRequest{
+				Path:        path.Root(check.attribute),
+				ConfigValue: types.StringValue(check.value),
+			}
+			var resp validator.StringResponse
+			v.ValidateString(context.Background(), req, &resp)
+			if errs := resp.Diagnostics.Errors(); len(errs) > 0 {
+				return errors.New(errs[0].Detail())
+			}
+		}
+	}
+	return nil
+}
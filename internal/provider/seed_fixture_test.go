@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseSeedFixtureValidation covers fixture items that demoapp_item
+// would reject: they must fail when the fixture is loaded, naming the item.
+func TestParseSeedFixtureValidation(t *testing.T) {
+	long := strings.Repeat("a", maxItemNameLength+1)
+
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		wantErr string
+	}{
+		{"valid json", "json", `[{"name":"Web Server","description":"nginx"}]`, ""},
+		{"valid csv", "csv", "name,description\nWeb Server,nginx\n", ""},
+		{"json bad charset", "json", `[{"name":"ok"},{"name":"-bad"}]`, `item 1 ("-bad")`},
+		{"yaml name too long", "yaml", "items:\n  - name: " + long + "\n", `item 0 ("` + long + `")`},
+		{"csv description too long", "csv", "name,description\nok,ok\ndb," + strings.Repeat("d", maxItemDescriptionLength+1) + "\n", `CSV line 3 ("db")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSeedFixture([]byte(tt.content), tt.format)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("parseSeedFixture: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseSeedFixture error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
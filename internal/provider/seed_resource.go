@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ resource.Resource = &SeedResource{}
+var _ resource.ResourceWithModifyPlan = &SeedResource{}
+
+// seedDisplayKey is the private state key recording that the seed
+// published a display payload, so Delete knows to clear it.
+const seedDisplayKey = "seeded_display"
+
+// SeedResource populates Demo App from a fixture file (JSON, YAML or CSV).
+// The whole fixture is one resource: any change to its content replaces
+// the resource, which deletes what the old fixture created and seeds again.
+type SeedResource struct {
+	client *DemoAppClient
+}
+
+// SeedResourceModel maps to the Terraform configuration and state.
+type SeedResourceModel struct {
+	ID types.String `tfsdk:"id"`
+
+	// Source is a fixture file path or the fixture content itself
+	Source types.String `tfsdk:"source"`
+
+	// Format is one of json, yaml or csv
+	Format types.String `tfsdk:"format"`
+
+	// Display overrides any display payload in the fixture
+	Display types.String `tfsdk:"display"`
+
+	// ContentHash is the SHA-256 of the fixture content; a change re-seeds
+	ContentHash types.String `tfsdk:"content_hash"`
+
+	// ItemIDs are the IDs of the items this seed created
+	ItemIDs types.List `tfsdk:"item_ids"`
+}
+
+// NewSeedResource is the factory function.
+func NewSeedResource() resource.Resource {
+	return &SeedResource{}
+}
+
+// Metadata sets the resource type name: demoapp_seed
+func (r *SeedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seed"
+}
+
+// Schema defines what users can configure.
+func (r *SeedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Seeds Demo App with items (and optionally display content) from a JSON, YAML or CSV fixture. Changing the fixture re-seeds; destroying removes exactly what was created.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Same as content_hash.",
+				Computed:    true,
+			},
+
+			"source": schema.StringAttribute{
+				Description: "Path to a fixture file, or the fixture content itself. A single line that doesn't start with '{' or '[' is treated as a path.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"format": schema.StringAttribute{
+				Description: "Fixture format: json, yaml or csv. CSV fixtures need a header row with a name column and an optional description column.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("json", "yaml", "csv"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"display": schema.StringAttribute{
+				Description: "JSON to show in the display panel after seeding. Overrides the fixture's display payload, if any.",
+				Optional:    true,
+				Validators: []validator.String{
+					validJSON(maxDisplayDataBytes),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"content_hash": schema.StringAttribute{
+				Description: "SHA-256 of the fixture content. When it changes, the seed is replaced.",
+				Computed:    true,
+			},
+
+			"item_ids": schema.ListAttribute{
+				Description: "IDs of the items created by this seed, in fixture order.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (r *SeedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan loads the fixture at plan time so parse errors show up early
+// and a changed file (with unchanged config) still triggers a re-seed.
+func (r *SeedResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SeedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for apply if the source depends on other resources
+	if plan.Source.IsUnknown() || plan.Format.IsUnknown() {
+		return
+	}
+
+	content, _, err := loadSeed(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Invalid Seed Source",
+			err.Error(),
+		)
+		return
+	}
+
+	hash := seedContentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), hash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state SeedResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ContentHash.ValueString() != hash {
+		resp.RequiresReplace.Append(path.Root("content_hash"))
+	}
+}
+
+// Create creates every item in the fixture, then publishes the display payload.
+func (r *SeedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SeedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 1. Load the fixture again; the file may have been edited since plan
+	content, fixture, err := loadSeed(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Invalid Seed Source",
+			err.Error(),
+		)
+		return
+	}
+
+	hash := seedContentHash(content)
+	if !plan.ContentHash.IsUnknown() && plan.ContentHash.ValueString() != hash {
+		resp.Diagnostics.AddError(
+			"Seed Fixture Changed",
+			"The fixture content changed between plan and apply. Run terraform plan again.",
+		)
+		return
+	}
+	plan.ContentHash = types.StringValue(hash)
+	plan.ID = types.StringValue(hash)
+
+	// 2. Create the items. On error we still save the IDs created so far,
+	// so destroy can clean them up.
+	ids := make([]string, 0, len(fixture.Items))
+	for i, item := range fixture.Items {
+		created, err := r.client.CreateItem(ctx, itemAPIModel{
+			Name:        item.Name,
+			Description: item.Description,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Seeding Items",
+				fmt.Sprintf("Could not create item %d (%q): %s", i, item.Name, err),
+			)
+			break
+		}
+		ids = append(ids, strconv.Itoa(created.ID))
+	}
+
+	itemIDs, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.ItemIDs = itemIDs
+
+	// 3. Publish the display payload, if there is one
+	display := string(fixture.Display)
+	if !plan.Display.IsNull() {
+		display = plan.Display.ValueString()
+	}
+	if display != "" && !resp.Diagnostics.HasError() {
+		if err := r.client.SetDisplay(ctx, "", display); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Seeding Display",
+				"Could not set display content: "+err.Error(),
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, seedDisplayKey, []byte("true"))...)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read drops created items that no longer exist. If every item is gone
+// (e.g. demo-app restarted with an empty database) the seed is removed
+// from state, so the next apply seeds again.
+func (r *SeedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SeedResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.ItemIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() || len(ids) == 0 {
+		return
+	}
+
+	items, err := r.client.ListItems(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seed",
+			"Could not list items: "+err.Error(),
+		)
+		return
+	}
+
+	live := make(map[string]bool, len(items))
+	for _, item := range items {
+		live[strconv.Itoa(item.ID)] = true
+	}
+
+	remaining := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if live[id] {
+			remaining = append(remaining, id)
+		}
+	}
+
+	if len(remaining) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	itemIDs, diags := types.ListValueFrom(ctx, types.StringType, remaining)
+	resp.Diagnostics.Append(diags...)
+	state.ItemIDs = itemIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with real changes — every input requires
+// replacement — but the framework requires it. Just keep the plan.
+func (r *SeedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SeedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes exactly the items this seed created, and clears the
+// display if the seed set it.
+func (r *SeedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SeedResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.ItemIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range ids {
+		if err := r.client.DeleteItem(ctx, id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Seeded Item",
+				fmt.Sprintf("Could not delete item %s: %s", id, err),
+			)
+			return
+		}
+	}
+
+	seededDisplay, diags := req.Private.GetKey(ctx, seedDisplayKey)
+	resp.Diagnostics.Append(diags...)
+	if string(seededDisplay) != "true" {
+		return
+	}
+
+	if err := r.client.SetDisplay(ctx, "", "{}"); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Clearing Seeded Display",
+			"Could not clear display content: "+err.Error(),
+		)
+	}
+}
+
+// loadSeed reads and parses the fixture described by the model.
+func loadSeed(m SeedResourceModel) ([]byte, *seedFixture, error) {
+	content, err := loadSeedSource(m.Source.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixture, err := parseSeedFixture(content, m.Format.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, fixture, nil
+}
@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time interface checks
+var _ ephemeral.EphemeralResource = &SessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &SessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithRenew = &SessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &SessionEphemeralResource{}
+
+// sessionPrivateKey is the private data key holding sessionPrivateState.
+const sessionPrivateKey = "session"
+
+// sessionRenewMargin is how long before expiry a session is renewed, so a
+// slow refresh doesn't leave Terraform holding an expired token.
+const sessionRenewMargin = 30 * time.Second
+
+// SessionEphemeralResource is demoapp_session: a short-lived Demo App
+// session token. Unlike a resource, it's opened fresh on every Terraform
+// run and closed (revoked) at the end, and the token is never written to
+// plan or state.
+//
+// Lifecycle:
+//   - Open: POST /api/auth/token with the credentials
+//   - Renew: POST /api/auth/refresh while Terraform still needs the token
+//   - Close: POST /api/auth/revoke once Terraform is done with it
+type SessionEphemeralResource struct {
+	client *DemoAppClient
+}
+
+// SessionEphemeralResourceModel maps to the Terraform configuration.
+type SessionEphemeralResourceModel struct {
+	// Username and Password are exchanged for the token
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+
+	// Token is the bearer token for the session
+	Token types.String `tfsdk:"token"`
+
+	// ExpiresAt is when the token stops working unless renewed (RFC 3339)
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// sessionAPIModel is the JSON returned by the /api/auth endpoints.
+type sessionAPIModel struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// ExpiresIn is the token lifetime in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// sessionPrivateState is kept in the ephemeral resource's private data
+// between Open, Renew and Close. Terraform holds it in memory only.
+type sessionPrivateState struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// NewSessionEphemeralResource is the factory function.
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &SessionEphemeralResource{}
+}
+
+// Metadata sets the ephemeral resource type name: demoapp_session
+func (e *SessionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+// Schema defines what users can configure.
+func (e *SessionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues a short-lived Demo App session token. The token is renewed while Terraform runs, revoked when it finishes, and never stored in plan or state. Requires Terraform 1.10+.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: "Username to authenticate as.",
+				Required:    true,
+			},
+
+			"password": schema.StringAttribute{
+				Description: "Password for username.",
+				Required:    true,
+				Sensitive:   true,
+			},
+
+			"token": schema.StringAttribute{
+				Description: "The session's bearer token, e.g. for the provider's token attribute.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"expires_at": schema.StringAttribute{
+				Description: "When the token expires unless renewed (RFC 3339, UTC). Terraform renews it automatically while it's still needed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure receives the provider's HTTP client.
+func (e *SessionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*DemoAppClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *DemoAppClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
+
+// Open exchanges the credentials for a session token.
+func (e *SessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// 1. Read the configuration
+	var config SessionEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 2. Ask Demo App for a session
+	session, err := e.client.CreateSession(ctx, config.Username.ValueString(), config.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Opening Session",
+			"Could not create session: "+err.Error(),
+		)
+		return
+	}
+	if session.Token == "" {
+		resp.Diagnostics.AddError(
+			"Error Opening Session",
+			"The API response did not contain a token.",
+		)
+		return
+	}
+
+	// 3. Remember what Renew and Close need
+	private := sessionPrivateState{
+		Token:        session.Token,
+		RefreshToken: session.RefreshToken,
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, private.encode())...)
+
+	// 4. Set the result and tell Terraform when to renew
+	expiresAt := time.Now().Add(time.Duration(session.ExpiresIn) * time.Second).UTC()
+	config.Token = types.StringValue(session.Token)
+	config.ExpiresAt = types.StringValue(expiresAt.Format(time.RFC3339))
+	resp.RenewAt = sessionRenewAt(session, expiresAt)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+// Renew extends the session while Terraform still needs the token.
+//
+// Terraform can't change an ephemeral result after Open, so whoever
+// received the token keeps using the same one; Renew only pushes its
+// expiry back.
+func (e *SessionEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	rawPrivate, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	private := decodeSessionPrivateState(rawPrivate)
+
+	// Without a refresh token there is nothing to renew with; the token
+	// simply lives until it expires
+	if private.RefreshToken == "" {
+		return
+	}
+
+	session, err := e.client.RefreshSession(ctx, private.RefreshToken)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Renewing Session",
+			"Could not refresh session: "+err.Error(),
+		)
+		return
+	}
+
+	if session.Token != "" && session.Token != private.Token {
+		resp.Diagnostics.AddWarning(
+			"Session Token Rotated",
+			"Demo App issued a new token when refreshing the session. Terraform keeps using the original token, which may stop working when it expires.",
+		)
+	}
+
+	// Refresh tokens may be rotated
+	if session.RefreshToken != "" {
+		private.RefreshToken = session.RefreshToken
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, private.encode())...)
+
+	expiresAt := time.Now().Add(time.Duration(session.ExpiresIn) * time.Second).UTC()
+	resp.RenewAt = sessionRenewAt(session, expiresAt)
+}
+
+// Close revokes the token once Terraform is done with it.
+func (e *SessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	rawPrivate, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	private := decodeSessionPrivateState(rawPrivate)
+	if private.Token == "" {
+		return
+	}
+
+	if err := e.client.RevokeSession(ctx, private.Token); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Closing Session",
+			"Could not revoke session token: "+err.Error(),
+		)
+	}
+}
+
+// sessionRenewAt is when Terraform should call Renew: a little before the
+// token expires. Sessions without a refresh token or expiry can't be
+// renewed, so it returns the zero time (no renewal).
+func sessionRenewAt(session *sessionAPIModel, expiresAt time.Time) time.Time {
+	if session.RefreshToken == "" || session.ExpiresIn <= 0 {
+		return time.Time{}
+	}
+
+	// For very short lifetimes, renew halfway instead
+	margin := sessionRenewMargin
+	if lifetime := time.Duration(session.ExpiresIn) * time.Second; lifetime < 2*margin {
+		margin = lifetime / 2
+	}
+	return expiresAt.Add(-margin)
+}
+
+// encode serializes the private data for SetKey.
+func (p sessionPrivateState) encode() []byte {
+	data, _ := json.Marshal(p)
+	return data
+}
+
+// decodeSessionPrivateState parses private data written by encode.
+func decodeSessionPrivateState(data []byte) sessionPrivateState {
+	var private sessionPrivateState
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &private)
+	}
+	return private
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// restapiObjectType is the resource type of the generic REST provider
+// (Mastercard/restapi) that older demos used to talk to Demo App.
+const restapiObjectType = "restapi_object"
+
+// restapiProviderAddress is the source address of that provider. Other
+// providers may have a restapi_object type too, with a different state.
+const restapiProviderAddress = "registry.terraform.io/mastercard/restapi"
+
+// restapiObject is the part of a restapi_object state we can move from.
+type restapiObject struct {
+	// Path is the collection the object was POSTed to, e.g. /api/items
+	Path string
+
+	// ID is the object ID as tracked by the restapi provider
+	ID string
+
+	// Data is the JSON the restapi provider sent
+	Data string
+
+	// APIResponse is the JSON the API returned for the object
+	APIResponse string
+}
+
+// readRestapiObject returns the restapi_object being moved, or nil if the
+// source isn't a Mastercard/restapi restapi_object, in which case the
+// mover should leave the request to the next one.
+func readRestapiObject(req resource.MoveStateRequest) (*restapiObject, error) {
+	// Provider addresses are case-insensitive
+	if req.SourceTypeName != restapiObjectType || !strings.EqualFold(req.SourceProviderAddress, restapiProviderAddress) {
+		return nil, nil
+	}
+
+	raw, err := decodeRawState(req.SourceRawState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restapiObject{
+		Path:        strings.TrimSuffix(raw.String("path").ValueString(), "/"),
+		ID:          raw.String("id").ValueString(),
+		Data:        raw.String("data").ValueString(),
+		APIResponse: raw.String("api_response").ValueString(),
+	}, nil
+}
+
+// item decodes the object as a Demo App item. The ID comes from the
+// restapi id if it's numeric, otherwise from the id field of the API
+// response. Name and description come from the API response, or from the
+// sent data if there's no response recorded.
+func (o *restapiObject) item() (itemAPIModel, error) {
+	var item itemAPIModel
+
+	body := o.APIResponse
+	if body == "" {
+		body = o.Data
+	}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &item); err != nil {
+			return item, fmt.Errorf("could not parse the restapi_object's api_response: %w", err)
+		}
+	}
+
+	if id, err := strconv.Atoi(o.ID); err == nil {
+		item.ID = id
+	}
+	if item.ID == 0 {
+		return item, fmt.Errorf("could not find a numeric item ID in the restapi_object's id (%q) or api_response", o.ID)
+	}
+
+	return item, nil
+}
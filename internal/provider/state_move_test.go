@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestReadRestapiObjectSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		address  string
+		want     bool
+	}{
+		{"mastercard", "restapi_object", "registry.terraform.io/mastercard/restapi", true},
+		{"mastercard mixed case", "restapi_object", "registry.terraform.io/Mastercard/restapi", true},
+		{"other provider", "restapi_object", "registry.terraform.io/example/restapi", false},
+		{"other type", "restapi_data", "registry.terraform.io/mastercard/restapi", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object, err := readRestapiObject(resource.MoveStateRequest{
+				SourceTypeName:        tt.typeName,
+				SourceProviderAddress: tt.address,
+				SourceRawState:        &tfprotov6.RawState{JSON: []byte(`{"id":"7","path":"/api/items/"}`)},
+			})
+			if err != nil {
+				t.Fatalf("readRestapiObject: %v", err)
+			}
+			if (object != nil) != tt.want {
+				t.Fatalf("readRestapiObject handled = %t, want %t", object != nil, tt.want)
+			}
+			if object != nil && (object.ID != "7" || object.Path != "/api/items") {
+				t.Errorf("object = %+v, want id 7 at /api/items", object)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// rawState is a prior resource state decoded attribute by attribute.
+//
+// The state upgraders read raw JSON instead of declaring a PriorSchema,
+// because "version 0" covers every release before schema versioning was
+// added. Early ones only had a few attributes; later ones added more
+// without bumping the version. Reading the JSON directly copes with both,
+// and quietly drops attributes that no longer exist.
+type rawState map[string]json.RawMessage
+
+// decodeRawState parses the JSON of a prior (or moved) state.
+func decodeRawState(state *tfprotov6.RawState) (rawState, error) {
+	if state == nil || len(state.JSON) == 0 {
+		return nil, fmt.Errorf("prior state has no JSON data")
+	}
+
+	var raw rawState
+	if err := json.Unmarshal(state.JSON, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse prior state: %w", err)
+	}
+	return raw, nil
+}
+
+// String returns a string attribute, or null if it's missing or null.
+func (s rawState) String(key string) types.String {
+	var v *string
+	if err := json.Unmarshal(s[key], &v); err != nil || v == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(*v)
+}
+
+// Bool returns a bool attribute, or null if it's missing or null.
+func (s rawState) Bool(key string) types.Bool {
+	var v *bool
+	if err := json.Unmarshal(s[key], &v); err != nil || v == nil {
+		return types.BoolNull()
+	}
+	return types.BoolValue(*v)
+}
+
+// Decode unmarshals an attribute into v, reporting whether it was present
+// and not null.
+func (s rawState) Decode(key string, v any) bool {
+	data, ok := s[key]
+	if !ok || string(data) == "null" {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
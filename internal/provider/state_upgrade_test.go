@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeState runs upgrader on a v0 state JSON fixture and returns the
+// upgraded state, which uses the current schema of r.
+func upgradeState(t *testing.T, r resource.Resource, upgrader func(context.Context, resource.UpgradeStateRequest, *resource.UpgradeStateResponse), fixture string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", schemaResp.Diagnostics)
+	}
+
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(fixture)},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade: %v", resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestUpgradeItemStateV0(t *testing.T) {
+	state := upgradeState(t, NewItemResource(), upgradeItemStateV0,
+		`{"id":"1","name":"a","description":""}`)
+
+	var got ItemResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+
+	if got.ID.ValueString() != "1" {
+		t.Errorf("id = %s, want 1", got.ID)
+	}
+	if got.Name.ValueString() != "a" {
+		t.Errorf("name = %s, want a", got.Name)
+	}
+	if !got.Description.IsNull() {
+		t.Errorf("description = %s, want null", got.Description)
+	}
+}
+
+func TestUpgradeDisplayStateV0(t *testing.T) {
+	state := upgradeState(t, NewDisplayResource(), upgradeDisplayStateV0,
+		`{"id":"display","data":"{\"b\":1,\"a\":2}\n"}`)
+
+	var got DisplayResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+
+	if want := `{"a":2,"b":1}`; got.Data.ValueString() != want {
+		t.Errorf("data = %s, want %s", got.Data, want)
+	}
+	if want := displayContentHash(`{"a":2,"b":1}`); !got.ContentSHA256.Equal(want) || got.ContentSHA256.ValueString() == "" {
+		t.Errorf("content_sha256 = %s, want %s", got.ContentSHA256, want)
+	}
+	if got.OnDestroy.ValueString() != displayOnDestroyClear {
+		t.Errorf("on_destroy = %s, want %s", got.OnDestroy, displayOnDestroyClear)
+	}
+	if got.IgnoreDeleteErrors.IsNull() || got.IgnoreDeleteErrors.ValueBool() {
+		t.Errorf("ignore_delete_errors = %s, want false", got.IgnoreDeleteErrors)
+	}
+	if got.ID.ValueString() != "display" {
+		t.Errorf("id = %s, want display", got.ID)
+	}
+}
+
+// TestUpgradeDisplayStateV0Escaping covers data with <, > and &, which
+// jsonencode() escapes. The upgraded data must be escaped the same way,
+// or the first plan after the upgrade shows a diff.
+func TestUpgradeDisplayStateV0Escaping(t *testing.T) {
+	state := upgradeState(t, NewDisplayResource(), upgradeDisplayStateV0,
+		`{"id":"display","data":"{\"html\":\"<b>R&D</b>\"}\n"}`)
+
+	var got DisplayResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+
+	if want := `{"html":"\u003cb\u003eR\u0026D\u003c/b\u003e"}`; got.Data.ValueString() != want {
+		t.Errorf("data = %s, want %s", got.Data, want)
+	}
+	if want := displayContentHash(`{"html":"<b>R&D</b>"}`); !got.ContentSHA256.Equal(want) {
+		t.Errorf("content_sha256 = %s, want %s", got.ContentSHA256, want)
+	}
+}
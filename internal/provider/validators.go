@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Limits for user input. Demo App would reject (or choke on) anything
+// bigger, so we catch it at plan time instead.
+const (
+	// maxItemNameLength is the longest item name we accept, in characters
+	maxItemNameLength = 128
+
+	// maxItemDescriptionLength is the longest item description we accept,
+	// in characters
+	maxItemDescriptionLength = 1024
+
+	// maxDisplayDataBytes is the largest display payload we accept
+	maxDisplayDataBytes = 64 * 1024
+)
+
+// itemNameRegexp allows letters, digits, spaces and a little punctuation,
+// and requires the name to start with a letter or digit.
+var itemNameRegexp = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} _.,:()/&+'#-]*$`)
+
+// itemNameCharsetMessage describes itemNameRegexp for diagnostics.
+const itemNameCharsetMessage = "must start with a letter or digit and contain only letters, digits, spaces and the characters _ . , : ( ) / & + ' # -"
+
+// itemNameValidators are the validators for every item name attribute.
+func itemNameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.UTF8LengthBetween(1, maxItemNameLength),
+		stringvalidator.RegexMatches(itemNameRegexp, itemNameCharsetMessage),
+	}
+}
+
+// itemDescriptionValidators are the validators for every item description attribute.
+func itemDescriptionValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.UTF8LengthAtMost(maxItemDescriptionLength),
+	}
+}
+
+// Compile-time interface check
+var _ validator.String = jsonStringValidator{}
+
+// jsonStringValidator checks that a string attribute holds valid JSON no
+// larger than maxBytes.
+type jsonStringValidator struct {
+	maxBytes int
+}
+
+// validJSON returns a validator for JSON string attributes such as display data.
+func validJSON(maxBytes int) jsonStringValidator {
+	return jsonStringValidator{maxBytes: maxBytes}
+}
+
+// Description describes the validation in plain text.
+func (v jsonStringValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be valid JSON of at most %d bytes", v.maxBytes)
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v jsonStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString runs the check. Unknown values (e.g. jsonencode() of
+// something computed) are skipped and checked again once known.
+func (v jsonStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if len(value) > v.maxBytes {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"JSON Too Large",
+			fmt.Sprintf("The value is %d bytes; the maximum is %d bytes.", len(value), v.maxBytes),
+		)
+		return
+	}
+
+	if !json.Valid([]byte(value)) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			"The value must be valid JSON. Use jsonencode() to convert HCL maps to JSON.",
+		)
+	}
+}
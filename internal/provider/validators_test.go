@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateString runs validators against value and reports whether it passed.
+func validateString(validators []validator.String, value string) bool {
+	for _, v := range validators {
+		req := validator.StringRequest{
+			Path:        path.Root("test"),
+			ConfigValue: types.StringValue(value),
+		}
+		var resp validator.StringResponse
+		v.ValidateString(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestItemNameValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"ascii", "Web Server", true},
+		{"empty", "", false},
+		{"max length", strings.Repeat("a", maxItemNameLength), true},
+		{"too long", strings.Repeat("a", maxItemNameLength+1), false},
+		// 50 characters, 150 bytes
+		{"cjk", strings.Repeat("服", 50), true},
+		{"cjk max length", strings.Repeat("服", maxItemNameLength), true},
+		{"cjk too long", strings.Repeat("服", maxItemNameLength+1), false},
+		{"bad start", "-web", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateString(itemNameValidators(), tt.value); got != tt.want {
+				t.Errorf("valid = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemDescriptionValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"empty", "", true},
+		{"cjk max length", strings.Repeat("説", maxItemDescriptionLength), true},
+		{"cjk too long", strings.Repeat("説", maxItemDescriptionLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateString(itemDescriptionValidators(), tt.value); got != tt.want {
+				t.Errorf("valid = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}